@@ -0,0 +1,206 @@
+package klse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Adjustment is the share split, consolidation or bonus issue of the
+// capital changes, the prices before the ex date are multiplied by
+// SharesBefore / SharesAfter and the volume by SharesAfter / SharesBefore.
+type Adjustment struct {
+	ExDate       TradingDate
+	Subject      string
+	Ratio        string
+	SharesBefore float64 // number of the shares held before the ex date, eg 2 of bonus issue "1 : 2".
+	SharesAfter  float64 // number of the shares held after the ex date, eg 3 of bonus issue "1 : 2".
+}
+
+// Factor is the price factor of the days before the ex date.
+func (a *Adjustment) Factor() float64 {
+	return a.SharesBefore / a.SharesAfter
+}
+
+// capitalChangeKind is the kind of capital change which changes the number of shares.
+type capitalChangeKind int
+
+const (
+	capitalChangeOther capitalChangeKind = iota // eg rights issue, private placement.
+	capitalChangeBonus
+	capitalChangeSplit
+)
+
+// capitalChangeKindOf is to get the kind of capital change of the subject,
+// eg "Bonus Issue", "Share Split", "Share Consolidation".
+func capitalChangeKindOf(subject string) capitalChangeKind {
+	subject = strings.ToLower(subject)
+	switch {
+	case strings.Contains(subject, "bonus") && !strings.Contains(subject, "warrant"):
+		return capitalChangeBonus
+	case strings.Contains(subject, "split"), strings.Contains(subject, "subdivision"),
+		strings.Contains(subject, "consolidation"):
+		return capitalChangeSplit
+	}
+	return capitalChangeOther
+}
+
+// parseRatio is to parse the ratio "a : b" of the capital change.
+func parseRatio(ratio string) (a, b float64, err error) {
+	parts := strings.Split(ratio, ":")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("klse: invalid ratio %q", ratio)
+	}
+	if a, err = parseFloat64(parts[0], -1); err != nil {
+		return 0, 0, err
+	}
+	if b, err = parseFloat64(parts[1], -1); err != nil {
+		return 0, 0, err
+	}
+	if a <= 0 || b <= 0 {
+		return 0, 0, fmt.Errorf("klse: invalid ratio %q", ratio)
+	}
+	return a, b, nil
+}
+
+// NewAdjustments is to get the split and bonus adjustments of the capital
+// changes, the other capital changes eg rights issue are ignored.
+// Bonus issue "a : b" is a new shares for every b shares held, share split
+// or consolidation "a : b" is every b shares held become a shares.
+// The capital changes which can't be parsed are in the report.
+func NewAdjustments(reports []*CapitalChangesReport) ([]*Adjustment, ParseReport) {
+	adjustments := []*Adjustment{}
+	var report ParseReport
+	for i, r := range reports {
+		kind := capitalChangeKindOf(r.Subject)
+		if kind == capitalChangeOther {
+			continue
+		}
+		field := elementField("capital_changes_reports", i)
+		if r.ExpireDate.IsZero() {
+			report = append(report, &ParseIssue{Field: field + "expired_date", Err: ErrNoValue})
+			continue
+		}
+		a, b, err := parseRatio(r.Ratio)
+		if err != nil {
+			report = append(report, &ParseIssue{Field: field + "ratio", Raw: r.Ratio, Err: err})
+			continue
+		}
+		adjustment := &Adjustment{
+			ExDate:       NewTradingDate(r.ExpireDate),
+			Subject:      r.Subject,
+			Ratio:        r.Ratio,
+			SharesBefore: b,
+			SharesAfter:  a,
+		}
+		if kind == capitalChangeBonus {
+			adjustment.SharesAfter = a + b
+		}
+		adjustments = append(adjustments, adjustment)
+	}
+	return adjustments, report
+}
+
+// AdjustOHLC is to back-adjust the prices and volume of the bars before the
+// ex date of every adjustment, the bars are copied and not changed.
+func AdjustOHLC(bars []*OHLC, adjustments []*Adjustment) []*OHLC {
+	adjusted := make([]*OHLC, 0, len(bars))
+	for _, bar := range bars {
+		before, after := adjustmentShares(bar.TradingDate(), adjustments)
+		adjusted = append(adjusted, scaleOHLC(bar, before, after))
+	}
+	return adjusted
+}
+
+// adjustmentShares is the shares held before and after all the adjustments
+// after the date, the price of the date is multiplied by before / after.
+func adjustmentShares(date TradingDate, adjustments []*Adjustment) (before, after float64) {
+	before, after = 1, 1
+	for _, adjustment := range adjustments {
+		if date.Before(adjustment.ExDate) {
+			before *= adjustment.SharesBefore
+			after *= adjustment.SharesAfter
+		}
+	}
+	return before, after
+}
+
+// scaleOHLC is to copy the bar with the prices x before / after and the
+// volume x after / before.
+func scaleOHLC(bar *OHLC, before, after float64) *OHLC {
+	scaled := *bar
+	if before == after {
+		return &scaled
+	}
+	factor := before / after
+	scaled.Open = roundFloat64(bar.Open*factor, 6)
+	scaled.High = roundFloat64(bar.High*factor, 6)
+	scaled.Low = roundFloat64(bar.Low*factor, 6)
+	scaled.Close = roundFloat64(bar.Close*factor, 6)
+	scaled.Volume = int(math.Round(float64(bar.Volume) / factor))
+	if bar.Exact != nil {
+		scale := func(d Decimal) Decimal {
+			return d.Mul(DecimalFromFloat(before)).Div(DecimalFromFloat(after))
+		}
+		scaled.Exact = &ExactOHLC{
+			Open:  scale(bar.Exact.Open),
+			High:  scale(bar.Exact.High),
+			Low:   scale(bar.Exact.Low),
+			Close: scale(bar.Exact.Close),
+		}
+	}
+	return &scaled
+}
+
+// AdjustedHistoricalData is to get the stock price data back-adjusted for
+// the share splits, consolidations and bonus issues with the default client.
+func AdjustedHistoricalData(code string, opts ...HistoricalOption) ([]*OHLC, error) {
+	return DefaultClient.AdjustedHistoricalData(code, opts...)
+}
+
+// AdjustedHistoricalDataContext is AdjustedHistoricalData with context
+// using the default client.
+func AdjustedHistoricalDataContext(ctx context.Context, code string, opts ...HistoricalOption) ([]*OHLC, error) {
+	return DefaultClient.AdjustedHistoricalDataContext(ctx, code, opts...)
+}
+
+// AdjustedHistoricalData is to get the stock price data back-adjusted for
+// the share splits, consolidations and bonus issues of the capital changes
+// of the company overview.
+func (c *Client) AdjustedHistoricalData(code string, opts ...HistoricalOption) ([]*OHLC, error) {
+	return c.AdjustedHistoricalDataContext(context.Background(), code, opts...)
+}
+
+// AdjustedHistoricalDataContext is AdjustedHistoricalData with context.
+func (c *Client) AdjustedHistoricalDataContext(ctx context.Context, code string, opts ...HistoricalOption) ([]*OHLC, error) {
+	_, adjustments, err := c.companyAdjustmentsContext(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	bars, err := c.GetStockHistoricalDataContext(ctx, code, opts...)
+	if bars == nil {
+		return nil, err
+	}
+	return AdjustOHLC(bars, adjustments), err
+}
+
+// companyAdjustmentsContext is to get the company overview and the adjustments
+// of its capital changes, the capital changes which can't be parsed are
+// logged and ignored.
+func (c *Client) companyAdjustmentsContext(ctx context.Context, code string) (*CompanyOverview, []*Adjustment, error) {
+	company, err := c.GetCompanyOverviewContext(ctx, code)
+	if err != nil && !(errors.Is(err, ErrSchemaDrift) && company != nil) {
+		return nil, nil, err
+	}
+	if err != nil {
+		c.logger.Log(LogWarning, err.Error(), Field{Key: "code", Value: code})
+	}
+	adjustments, report := NewAdjustments(company.CapitalChangesReports)
+	for _, issue := range report {
+		c.logger.Log(LogWarning, "capital change is not adjusted", Field{Key: "code", Value: code},
+			Field{Key: "field", Value: issue.Field}, Field{Key: "error", Value: issue.Err})
+	}
+	return company, adjustments, nil
+}
@@ -0,0 +1,118 @@
+package klse_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	klse "github.com/kokweikhong/klsescreener-scraper"
+)
+
+// stockServer is to serve the stock page with the capital changes and
+// dividends rows, and the chart of daily bars.
+func stockServer(chart string, capitalChanges, dividends []string) *testServer {
+	return newTestServer(func(r *http.Request) string {
+		if strings.HasPrefix(r.URL.Path, "/v2/stocks/chart/") {
+			return chartPage(chart)
+		}
+		return `<html><body>` +
+			`<div id="capital_changes"><table><thead><tr><th>Announced</th><th>EX Date</th><th>Subject</th>` +
+			`<th>Ratio</th><th>Offer Price</th><th></th></tr></thead><tbody>` + strings.Join(capitalChanges, "") + `</tbody></table></div>` +
+			`<div id="dividends"><table><thead><tr><th>Announced</th><th>Financial Year</th><th>Subject</th><th>EX Date</th>` +
+			`<th>Payment Date</th><th>Amount</th><th>Indicator</th><th></th></tr></thead><tbody>` + strings.Join(dividends, "") + `</tbody></table></div>` +
+			`</body></html>`
+	})
+}
+
+// capitalChangeRow is the row of capital changes table.
+func capitalChangeRow(exDate, subject, ratio string) string {
+	return `<tr><td>01 Jan 2022</td><td>` + exDate + `</td><td>` + subject + `</td><td>` + ratio +
+		`</td><td>-</td><td><a href="/v2/announcements/view/1">View</a></td></tr>`
+}
+
+func TestNewAdjustments(t *testing.T) {
+	exDate := time.Date(2021, 2, 2, 0, 0, 0, 0, klse.MYT)
+	adjustments, report := klse.NewAdjustments([]*klse.CapitalChangesReport{
+		{ExpireDate: exDate, Subject: "Bonus Issue", Ratio: "1 : 2"},
+		{ExpireDate: exDate, Subject: "Share Split", Ratio: "10 : 1"},
+		{ExpireDate: exDate, Subject: "Share Consolidation", Ratio: "1 : 5"},
+		{ExpireDate: exDate, Subject: "Rights Issue", Ratio: "1 : 4"},
+		{ExpireDate: exDate, Subject: "Bonus Issue of Warrants", Ratio: "1 : 4"},
+		{ExpireDate: exDate, Subject: "Bonus Issue", Ratio: "-"},
+		{Subject: "Share Split", Ratio: "2 : 1"},
+	})
+	want := []float64{2.0 / 3, 0.1, 5}
+	if len(adjustments) != len(want) {
+		t.Fatalf("adjustments = %d, want %d", len(adjustments), len(want))
+	}
+	for i, factor := range want {
+		if adjustments[i].Factor() != factor || adjustments[i].ExDate.String() != "2021-02-02" {
+			t.Errorf("adjustments[%d] = %+v, want factor %v", i, adjustments[i], factor)
+		}
+	}
+	if len(report) != 2 || report.Valid("capital_changes_reports[5].ratio") || report.Valid("capital_changes_reports[6].expired_date") {
+		t.Errorf("report = %v", report.Err())
+	}
+}
+
+func TestAdjustedHistoricalData(t *testing.T) {
+	chart := `[1654012800000,1.5,1.8,1.2,1.5,100],` + // 2022-06-01
+		`[1656604800000,1,1.2,0.9,1,150],` + // 2022-07-01
+		`[1657209600000,0.5,0.6,0.4,0.5,300],` // 2022-07-08
+	server := stockServer(chart, []string{
+		capitalChangeRow("08 Jul 2022", "Share Split", "2 : 1"),
+		capitalChangeRow("01 Jul 2022", "Bonus Issue", "1 : 2"),
+		capitalChangeRow("01 Jun 2022", "Rights Issue", "1 : 4"),
+	}, nil)
+	defer server.Close()
+	client := klse.NewClient(klse.WithBaseURL(server.URL), klse.WithChartRateLimit(0, 0), klse.WithExactDecimals())
+
+	data, err := client.AdjustedHistoricalData("7251")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []klse.OHLC{
+		{Open: 0.5, High: 0.6, Low: 0.4, Close: 0.5, Volume: 300},
+		{Open: 0.5, High: 0.6, Low: 0.45, Close: 0.5, Volume: 300},
+		{Open: 0.5, High: 0.6, Low: 0.4, Close: 0.5, Volume: 300},
+	}
+	if len(data) != len(want) {
+		t.Fatalf("data = %d, want %d", len(data), len(want))
+	}
+	for i, w := range want {
+		d := data[i]
+		if d.Open != w.Open || d.High != w.High || d.Low != w.Low || d.Close != w.Close || d.Volume != w.Volume {
+			t.Errorf("data[%d] = %+v, want %+v", i, d, w)
+		}
+		if d.Exact.Close.String() != "0.5" {
+			t.Errorf("data[%d] exact close = %v", i, d.Exact.Close)
+		}
+	}
+
+	unadjusted, err := client.GetStockHistoricalData("7251")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unadjusted[0].Close != 1.5 {
+		t.Errorf("unadjusted close = %v", unadjusted[0].Close)
+	}
+}
+
+func TestAdjustedHistoricalDataNullClose(t *testing.T) {
+	chart := `[1654012800000,1.5,1.8,1.2,1.5,100],` + // 2022-06-01
+		`[1656604800000,1,1.2,0.9,null,150],` + // 2022-07-01
+		`[1657209600000,0.5,0.6,0.4,0.5,300],` // 2022-07-08
+	server := stockServer(chart, []string{capitalChangeRow("08 Jul 2022", "Share Split", "2 : 1")}, nil)
+	defer server.Close()
+	client := klse.NewClient(klse.WithBaseURL(server.URL), klse.WithChartRateLimit(0, 0))
+
+	data, err := client.AdjustedHistoricalData("7251")
+	if !errors.Is(err, klse.ErrSkippedRows) {
+		t.Fatalf("err = %v, want ErrSkippedRows", err)
+	}
+	if len(data) != 2 || data[0].Close != 0.75 || data[1].Close != 0.5 {
+		t.Errorf("data = %+v, want the null close skipped", data)
+	}
+}
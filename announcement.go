@@ -1,7 +1,7 @@
 package klse
 
 import (
-	"fmt"
+	"context"
 	"net/http"
 	"regexp"
 	"strings"
@@ -11,65 +11,92 @@ import (
 )
 
 // announcement is the initialise request for entitlements
-type announcement struct{}
+type announcement struct {
+	client *Client
+}
 
-// NewAnnouncementRequest is to initialise request for any announcement.
+// NewAnnouncementRequest is to initialise request for any announcement
+// with the default client.
 func NewAnnouncementRequest() *announcement {
-	return &announcement{}
+	return DefaultClient.NewAnnouncementRequest()
+}
+
+// NewAnnouncementRequest is to initialise request for any announcement.
+func (c *Client) NewAnnouncementRequest() *announcement {
+	return &announcement{client: c}
 }
 
 // DividentEntitlements is the entitlements data structure for dividend.
 type DividentEntitlements struct {
-	ExpireDate time.Time `json:"expired_date"`
-	Name       string    `json:"name"`
-	Code       string    `json:"code"`
-	Subject    string    `json:"subject"`
-	Amount     float64   `json:"amount"`
-	Type       string    `json:"type"`
-	ReportLink string    `json:"report_link"`
+	ExpireDate time.Time      `json:"expired_date"`
+	Name       string         `json:"name"`
+	Code       string         `json:"code"`
+	Subject    string         `json:"subject"`
+	Amount     float64        `json:"amount"`
+	Type       string         `json:"type"`
+	ReportLink string         `json:"report_link"`
+	Report     ParseReport    `json:"parse_report,omitempty"`
+	Exact      *ExactDividend `json:"exact,omitempty"` // with WithExactDecimals only.
 }
 
 // GetRecentDividendEntitlements is to get recent divident entitlements.
-func (*announcement) GetRecentDividendEntitlements() []*DividentEntitlements {
-	entitlements := []*DividentEntitlements{}
-	url := "https://www.klsescreener.com/v2/entitlements/dividends"
-	resp := newRequest(http.MethodGet, url, nil)
-	defer resp.Body.Close()
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+func (a *announcement) GetRecentDividendEntitlements() ([]*DividentEntitlements, error) {
+	return a.GetRecentDividendEntitlementsContext(context.Background())
+}
+
+// GetRecentDividendEntitlementsContext is GetRecentDividendEntitlements with context.
+func (a *announcement) GetRecentDividendEntitlementsContext(ctx context.Context) ([]*DividentEntitlements, error) {
+	url := a.client.baseURL + "/v2/entitlements/dividends"
+	p := a.client.newParser(ctx, url)
+	resp, err := a.client.newRequest(ctx, http.MethodGet, "/v2/entitlements/dividends", nil)
 	if err != nil {
-		logError.Fatalf("%s, %s, %s\n", url, resp.Status, err.Error())
+		return nil, err
 	}
-	doc.Find(`table tbody tr`).Each(func(trIndex int, tr *goquery.Selection) {
+	defer resp.Body.Close()
+	return parseDocument(p, resp.Body, p.parseDividendEntitlements)
+}
+
+// parseDividendEntitlements is to parse the dividend entitlements table.
+func (p *parser) parseDividendEntitlements(doc *goquery.Document) []*DividentEntitlements {
+	entitlements := []*DividentEntitlements{}
+	table := doc.Find(`table`)
+	columns := p.tableColumns("dividend_entitlements", table,
+		"EX Date", "Stock", "Subject", "Amount", "Type", "")
+	table.Find(`tbody tr`).EachWithBreak(func(trIndex int, tr *goquery.Selection) bool {
+		if p.ctx.Err() != nil {
+			return false
+		}
 		td := tr.Find(`td`)
 		if len(td.Nodes) < 6 {
-			return
+			return true
 		}
 		entitlement := &DividentEntitlements{}
 		td.Each(func(i int, element *goquery.Selection) {
-			switch i {
-			case 0:
-				entitlement.ExpireDate = convertStringToDate(
-					removeAllSpaces(element.Text(), " ")+fmt.Sprintf(" %v", time.Now().Year()),
-					"02 Jan 2006",
-				)
-			case 1:
+			switch columns.name(i) {
+			case "EX Date":
+				entitlement.ExpireDate = p.dateWithoutYear("expired_date",
+					removeAllSpaces(element.Text(), " "), "02 Jan", nearestWindow)
+			case "Stock":
 				href, _ := element.Find(`a`).Attr("href")
 				codeString := strings.Split(href, "/")
 				entitlement.Code = codeString[len(codeString)-1]
 				entitlement.Name = removeAllSpaces(element.Text(), " ")
-			case 2:
+			case "Subject":
 				entitlement.Subject = removeAllSpaces(element.Text(), " ")
-			case 3:
-				entitlement.Amount = convertStringToFloat64(removeAllSpaces(element.Text(), ""), 6)
-			case 4:
+			case "Amount":
+				entitlement.Amount = p.float("amount", removeAllSpaces(element.Text(), ""), 6)
+				entitlement.Exact = p.exactDividend("amount", removeAllSpaces(element.Text(), ""))
+			case "Type":
 				entitlement.Type = removeAllSpaces(element.Text(), " ")
-			case 5:
+			case "":
 				href, _ := element.Find(`a`).Attr("href")
 				entitlement.ReportLink = href
 			}
 		})
+		entitlement.Report = p.takeReport()
 		entitlements = append(entitlements, entitlement)
-		logInfo.Printf("getting data no %d : %v\n", trIndex+1, entitlement)
+		p.log(LogDebug, "getting dividend entitlement", Field{Key: "row", Value: trIndex}, Field{Key: "data", Value: entitlement})
+		return true
 	})
 	return entitlements
 }
@@ -82,56 +109,86 @@ type ShareIssuedEntitlements struct {
 
 // shareIssued is the data structure for details of shares issued.
 type shareIssued struct {
-	ExpireDate time.Time `json:"expired_date"`
-	Name       string    `json:"name"`
-	Code       string    `json:"code"`
-	Subject    string    `json:"subject"`
-	Ratio      string    `json:"ratio"`
-	OfferPrice float64   `json:"offer_price"`
-	Type       string    `json:"type"`
-	ReportLink string    `json:"report_link"`
+	ExpireDate time.Time         `json:"expired_date"`
+	Name       string            `json:"name"`
+	Code       string            `json:"code"`
+	Subject    string            `json:"subject"`
+	Ratio      string            `json:"ratio"`
+	OfferPrice float64           `json:"offer_price"`
+	Type       string            `json:"type"`
+	ReportLink string            `json:"report_link"`
+	Report     ParseReport       `json:"parse_report,omitempty"`
+	Exact      *ExactShareIssued `json:"exact,omitempty"` // with WithExactDecimals only.
+}
+
+// ExactShareIssued is the exact decimal offer price of shares issued.
+type ExactShareIssued struct {
+	OfferPrice Decimal `json:"offer_price"`
 }
 
 // GetShareIssuedEntitlements is to get UPCOMING and RECENT share issues entitlements.
-func (*announcement) GetShareIssuedEntitlements() *ShareIssuedEntitlements {
-	entitlement := &ShareIssuedEntitlements{}
-	url := "https://www.klsescreener.com/v2/entitlements/shares-issue"
-	resp := newRequest(http.MethodGet, url, nil)
-	defer resp.Body.Close()
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+func (a *announcement) GetShareIssuedEntitlements() (*ShareIssuedEntitlements, error) {
+	return a.GetShareIssuedEntitlementsContext(context.Background())
+}
+
+// GetShareIssuedEntitlementsContext is GetShareIssuedEntitlements with context.
+func (a *announcement) GetShareIssuedEntitlementsContext(ctx context.Context) (*ShareIssuedEntitlements, error) {
+	url := a.client.baseURL + "/v2/entitlements/shares-issue"
+	p := a.client.newParser(ctx, url)
+	resp, err := a.client.newRequest(ctx, http.MethodGet, "/v2/entitlements/shares-issue", nil)
 	if err != nil {
-		logError.Fatalf("%s, %s : %s\n", url, resp.Status, err.Error())
+		return nil, err
 	}
-	doc.Find(`table tbody`).Each(func(tableIndex int, table *goquery.Selection) {
-		table.Find(`tr`).Each(func(_ int, tr *goquery.Selection) {
+	defer resp.Body.Close()
+	return parseDocument(p, resp.Body, p.parseShareIssuedEntitlements)
+}
+
+// parseShareIssuedEntitlements is to parse the recent and upcoming share issues tables.
+func (p *parser) parseShareIssuedEntitlements(doc *goquery.Document) *ShareIssuedEntitlements {
+	entitlement := &ShareIssuedEntitlements{}
+	doc.Find(`table`).Each(func(tableIndex int, table *goquery.Selection) {
+		name, window := "recent_share_issues", recentWindow
+		if tableIndex > 0 {
+			name, window = "upcoming_share_issues", upcomingWindow
+		}
+		columns := p.tableColumns(name, table,
+			"EX Date", "Stock", "Subject", "Ratio", "Offer Price", "Type", "")
+		table.Find(`tbody tr`).EachWithBreak(func(trIndex int, tr *goquery.Selection) bool {
+			if p.ctx.Err() != nil {
+				return false
+			}
 			td := tr.Find("td")
 			if len(td.Nodes) < 7 {
-				return
+				return true
 			}
 			var expireDate time.Time
 			var name, subject, code, ratio, reportLink, typeOfEntitlement string
 			var offerPrice float64
+			var exact *ExactShareIssued
 			td.Each(func(i int, element *goquery.Selection) {
 				text := removeAllSpaces(element.Text(), " ")
-				switch i {
-				case 0:
-					expireDate = convertStringToDate(fmt.Sprintf("%s %d", text, time.Now().Year()), "02 Jan 2006")
-				case 1:
+				switch columns.name(i) {
+				case "EX Date":
+					expireDate = p.dateWithoutYear("expired_date", text, "02 Jan", window)
+				case "Stock":
 					name = text
 					codeHref, _ := element.Find("a").Attr("href")
 					codeSplit := strings.Split(codeHref, "/")
 					if len(codeSplit) > 1 {
 						code = codeSplit[len(codeSplit)-1]
 					}
-				case 2:
+				case "Subject":
 					subject = text
-				case 3:
+				case "Ratio":
 					ratio = text
-				case 4:
-					offerPrice = convertStringToFloat64(text, 4)
-				case 5:
+				case "Offer Price":
+					offerPrice = p.float("offer_price", text, 4)
+					if p.exact {
+						exact = &ExactShareIssued{OfferPrice: p.decimal("offer_price", text)}
+					}
+				case "Type":
 					typeOfEntitlement = text
-				case 6:
+				case "":
 					reportLink, _ = element.Find("a").Attr("href")
 				}
 			})
@@ -144,6 +201,8 @@ func (*announcement) GetShareIssuedEntitlements() *ShareIssuedEntitlements {
 				Type:       typeOfEntitlement,
 				ReportLink: reportLink,
 				OfferPrice: offerPrice,
+				Report:     p.takeReport(),
+				Exact:      exact,
 			}
 			switch tableIndex {
 			case 0:
@@ -151,7 +210,8 @@ func (*announcement) GetShareIssuedEntitlements() *ShareIssuedEntitlements {
 			case 1:
 				entitlement.UpcomingShareIssues = append(entitlement.UpcomingShareIssues, report)
 			}
-			logInfo.Printf("getting share issues data : %v\n", report)
+			p.log(LogDebug, "getting share issues entitlement", Field{Key: "row", Value: trIndex}, Field{Key: "data", Value: report})
+			return true
 		})
 	})
 	return entitlement
@@ -159,90 +219,110 @@ func (*announcement) GetShareIssuedEntitlements() *ShareIssuedEntitlements {
 
 // QuarterReportAnnouncement is the data structe for the details of quarter report announcement.
 type QuarterReportAnnouncement struct {
-	AnnouncedDate     time.Time `json:"announced_date"`
-	Name              string    `json:"name"`
-	Code              string    `json:"code"`
-	Quarter           int       `json:"quarter"`
-	QuarterReportDate time.Time `json:"quarter_report_date"`
-	Revenue           float64   `json:"revenue"`
-	RevenuePrecent    float64   `json:"revenue_percentage"`
-	NetProfit         float64   `json:"net_profit"`
-	QoQPercent        float64   `json:"qoq_percentage"`
-	YoYPercent        float64   `json:"yoy_percentage"`
-	EPS               float64   `json:"eps"`
-	Dividend          float64   `json:"dividend"`
-	ReportLink        string    `json:"report_link"`
+	AnnouncedDate     time.Time   `json:"announced_date"`
+	Name              string      `json:"name"`
+	Code              string      `json:"code"`
+	Quarter           int         `json:"quarter"`
+	QuarterReportDate time.Time   `json:"quarter_report_date"`
+	Revenue           float64     `json:"revenue"`
+	RevenuePrecent    float64     `json:"revenue_percentage"`
+	NetProfit         float64     `json:"net_profit"`
+	QoQPercent        float64     `json:"qoq_percentage"`
+	YoYPercent        float64     `json:"yoy_percentage"`
+	EPS               float64     `json:"eps"`
+	Dividend          float64     `json:"dividend"`
+	ReportLink        string      `json:"report_link"`
+	Report            ParseReport `json:"parse_report,omitempty"`
 }
 
 // GetQuarterReportAnnouncement is to get recent quarterly report announcements.
-func (*announcement) GetQuarterReportAnnouncement() []*QuarterReportAnnouncement {
-	reports := []*QuarterReportAnnouncement{}
-	url := "https://www.klsescreener.com/v2/financial-reports"
-	resp := newRequest(http.MethodGet, url, nil)
-	defer resp.Body.Close()
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+func (a *announcement) GetQuarterReportAnnouncement() ([]*QuarterReportAnnouncement, error) {
+	return a.GetQuarterReportAnnouncementContext(context.Background())
+}
+
+// GetQuarterReportAnnouncementContext is GetQuarterReportAnnouncement with context.
+func (a *announcement) GetQuarterReportAnnouncementContext(ctx context.Context) ([]*QuarterReportAnnouncement, error) {
+	url := a.client.baseURL + "/v2/financial-reports"
+	p := a.client.newParser(ctx, url)
+	resp, err := a.client.newRequest(ctx, http.MethodGet, "/v2/financial-reports", nil)
 	if err != nil {
-		logError.Fatalf("%s, %s : %s\n", url, resp.Status, err.Error())
+		return nil, err
 	}
+	defer resp.Body.Close()
+	return parseDocument(p, resp.Body, p.parseQuarterReportAnnouncement)
+}
+
+// parseQuarterReportAnnouncement is to parse the quarter report announcements table.
+func (p *parser) parseQuarterReportAnnouncement(doc *goquery.Document) []*QuarterReportAnnouncement {
+	reports := []*QuarterReportAnnouncement{}
 	regexpFloat := regexp.MustCompile(`\d+([\,]\d+)*([\.]\d+)?`)
-	doc.Find(`table tbody tr`).Each(func(_ int, tr *goquery.Selection) {
+	table := doc.Find(`table`)
+	columns := p.tableColumns("quarter_report_announcement", table,
+		"Announced", "Stock", "Quarter", "Q Date", "Revenue", "Revenue %", "Net Profit", "QoQ", "YoY", "EPS", "Dividend", "")
+	table.Find(`tbody tr`).EachWithBreak(func(trIndex int, tr *goquery.Selection) bool {
+		if p.ctx.Err() != nil {
+			return false
+		}
 		td := tr.Find("td")
 		if len(td.Nodes) < 12 {
-			return
+			return true
 		}
 		report := &QuarterReportAnnouncement{}
 		td.Each(func(i int, element *goquery.Selection) {
 			text := removeAllSpaces(element.Text(), " ")
 			span := element.Find("span").First()
-			switch i {
-			case 0:
-				report.AnnouncedDate = convertStringToDate(fmt.Sprintf("%s %d", text, time.Now().Year()), "02 Jan 2006")
-			case 1:
+			switch columns.name(i) {
+			case "Announced":
+				report.AnnouncedDate = p.dateWithoutYear("announced_date", text, "02 Jan", recentWindow)
+			case "Stock":
 				report.Name = text
 				codeHref, _ := element.Find("a").First().Attr("href")
 				codeSplit := strings.Split(codeHref, "/")
 				if len(codeSplit) > 1 {
 					report.Code = codeSplit[len(codeSplit)-1]
 				}
-			case 2:
-				report.Quarter = int(convertStringToFloat64(text, 0))
-			case 3:
-				report.QuarterReportDate = convertStringToDate(text, "2006-01-02")
-			case 4:
-				report.Revenue = convertStringToFloat64(text, 0)
-			case 5:
+			case "Quarter":
+				report.Quarter = p.integer("quarter", text)
+			case "Q Date":
+				report.QuarterReportDate = p.date("quarter_report_date", text, "2006-01-02")
+			case "Revenue":
+				report.Revenue = p.float("revenue", text, 0)
+			case "Revenue %":
 				class, _ := span.Attr("class")
 				floatText := regexpFloat.FindString(span.Text())
-				report.RevenuePrecent = convertStringToFloat64(removeAllSpaces(floatText, ""), 2)
+				report.RevenuePrecent = p.float("revenue_percentage", removeAllSpaces(floatText, ""), 2)
 				if strings.Contains(class, "decreasing") {
 					report.RevenuePrecent = -(report.RevenuePrecent)
 				}
-			case 6:
-				report.NetProfit = convertStringToFloat64(text, 0)
-			case 7:
+			case "Net Profit":
+				report.NetProfit = p.float("net_profit", text, 0)
+			case "QoQ":
 				class, _ := span.Attr("class")
 				floatText := regexpFloat.FindString(span.Text())
-				report.QoQPercent = convertStringToFloat64(removeAllSpaces(floatText, ""), 4)
+				report.QoQPercent = p.float("qoq_percentage", removeAllSpaces(floatText, ""), 4)
 				if strings.Contains(class, "decreasing") {
 					report.QoQPercent = -(report.QoQPercent)
 				}
-			case 8:
+			case "YoY":
 				class, _ := span.Attr("class")
 				floatText := regexpFloat.FindString(span.Text())
-				report.YoYPercent = convertStringToFloat64(removeAllSpaces(floatText, ""), 4)
+				report.YoYPercent = p.float("yoy_percentage", removeAllSpaces(floatText, ""), 4)
 				if strings.Contains(class, "negative") {
 					report.YoYPercent = -(report.YoYPercent)
 				}
-			case 9:
-				report.EPS = convertStringToFloat64(text, 2)
-			case 10:
-				report.Dividend = convertStringToFloat64(text, 3)
-			case 11:
+			case "EPS":
+				report.EPS = p.float("eps", text, 2)
+			case "Dividend":
+				report.Dividend = p.float("dividend", text, 3)
+			case "":
 				report.ReportLink, _ = element.Find("a").First().Attr("href")
                 report.ReportLink = klescreenerBaseURL + report.ReportLink
 			}
 		})
+		report.Report = p.takeReport()
 		reports = append(reports, report)
+		p.log(LogDebug, "getting quarter report announcement", Field{Key: "row", Value: trIndex}, Field{Key: "data", Value: report})
+		return true
 	})
 	return reports
 }
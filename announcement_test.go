@@ -1,31 +1,61 @@
 package klse_test
 
 import (
-	"encoding/json"
-	"fmt"
 	"testing"
+	"time"
 
 	klse "github.com/kokweikhong/klsescreener-scraper"
 )
 
 func TestGetDividendEntitlements(t *testing.T) {
-    annoucement := klse.NewAnnouncementRequest()
-    data := annoucement.GetRecentDividendEntitlements()
-    b, _ := json.MarshalIndent(data, "", "  ")
-    fmt.Println(string(b))
+	annoucement := newFixtureClient(t, klse.WithClock(fixtureClock)).NewAnnouncementRequest()
+	data, err := annoucement.GetRecentDividendEntitlements()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 2 {
+		t.Fatalf("data = %d, want 2", len(data))
+	}
+	dividend := data[1]
+	if dividend.Code != "1155" || dividend.Name != "MAYBANK" || dividend.Amount != 0.0125 ||
+		!dividend.ExpireDate.Equal(time.Date(2022, 6, 13, 0, 0, 0, 0, klse.MYT)) {
+		t.Errorf("dividend = %+v", dividend)
+	}
 }
 
 func TestGetShareIssuedEntitlements(t *testing.T) {
-    annoucement := klse.NewAnnouncementRequest()
-    data := annoucement.GetShareIssuedEntitlements()
-    b, _ := json.MarshalIndent(data, "", "  ")
-    fmt.Println(string(b))
+	annoucement := newFixtureClient(t, klse.WithClock(fixtureClock)).NewAnnouncementRequest()
+	data, err := annoucement.GetShareIssuedEntitlements()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data.RecentShareIssues) != 1 || len(data.UpcomingShareIssues) != 1 {
+		t.Fatalf("data = %+v, want 1 recent and 1 upcoming", data)
+	}
+	upcoming := data.UpcomingShareIssues[0]
+	if upcoming.Code != "7251" || upcoming.Ratio != "1 : 4" || upcoming.OfferPrice != 0.05 || upcoming.Type != "Rights" ||
+		!upcoming.ExpireDate.Equal(time.Date(2022, 7, 8, 0, 0, 0, 0, klse.MYT)) {
+		t.Errorf("upcoming = %+v", upcoming)
+	}
 }
 
-
-func TestGetQuarterReportAnnouncement (t *testing.T) {
-    annoucement := klse.NewAnnouncementRequest()
-    data := annoucement.GetQuarterReportAnnouncement()
-    b, _ := json.MarshalIndent(data, "", "  ")
-    fmt.Println(string(b))
+func TestGetQuarterReportAnnouncement(t *testing.T) {
+	annoucement := newFixtureClient(t, klse.WithClock(fixtureClock)).NewAnnouncementRequest()
+	data, err := annoucement.GetQuarterReportAnnouncement()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 2 {
+		t.Fatalf("data = %d, want 2", len(data))
+	}
+	report := data[0]
+	if report.Code != "6947" || report.Quarter != 1 || report.Revenue != 1620150 || report.RevenuePrecent != -2.15 ||
+		report.QoQPercent != -12.5 || report.YoYPercent != 8.1 || report.EPS != 3.15 ||
+		!report.QuarterReportDate.Equal(time.Date(2022, 3, 31, 0, 0, 0, 0, klse.MYT)) ||
+		!report.AnnouncedDate.Equal(time.Date(2022, 4, 22, 0, 0, 0, 0, klse.MYT)) {
+		t.Errorf("report = %+v", report)
+	}
+	if data[1].YoYPercent != -1.3 {
+		t.Errorf("report = %+v", data[1])
+	}
 }
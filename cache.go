@@ -0,0 +1,270 @@
+package klse
+
+import (
+	"bufio"
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Endpoint is the family of klsescreener pages, used to set cache TTL.
+type Endpoint string
+
+const (
+	EndpointMarkets          Endpoint = "markets"           // /v2/markets
+	EndpointMarketHistorical Endpoint = "market_historical" // /v2/markets/historical_period/
+	EndpointQuoteResults     Endpoint = "quote_results"     // /v2/screener/quote_results
+	EndpointStockView        Endpoint = "stock_view"        // /v2/stocks/view/
+	EndpointChart            Endpoint = "chart"             // /v2/stocks/chart/
+	EndpointIntraday         Endpoint = "intraday"          // /v2/stocks/chart/<code>/embedded/1d
+	EndpointEntitlements     Endpoint = "entitlements"      // /v2/entitlements/
+	EndpointFinancialReports Endpoint = "financial_reports" // /v2/financial-reports
+	EndpointOther            Endpoint = "other"             // any other path
+)
+
+// endpointOf is to get the endpoint family of the url path.
+func endpointOf(path string) Endpoint {
+	switch {
+	case strings.HasPrefix(path, "/v2/markets/historical_period/"):
+		return EndpointMarketHistorical
+	case strings.HasPrefix(path, "/v2/markets"):
+		return EndpointMarkets
+	case strings.HasPrefix(path, "/v2/screener/quote_results"):
+		return EndpointQuoteResults
+	case strings.HasPrefix(path, companyOverviewPath):
+		return EndpointStockView
+	case strings.HasPrefix(path, "/v2/stocks/chart/") && strings.HasSuffix(path, intradayPathSuffix):
+		return EndpointIntraday
+	case strings.HasPrefix(path, "/v2/stocks/chart/"):
+		return EndpointChart
+	case strings.HasPrefix(path, "/v2/entitlements/"):
+		return EndpointEntitlements
+	case strings.HasPrefix(path, "/v2/financial-reports"):
+		return EndpointFinancialReports
+	}
+	return EndpointOther
+}
+
+// DefaultCacheTTL is the suggested cache TTL of every endpoint.
+func DefaultCacheTTL() map[Endpoint]time.Duration {
+	return map[Endpoint]time.Duration{
+		EndpointMarkets:          30 * time.Second,
+		EndpointMarketHistorical: 12 * time.Hour,
+		EndpointQuoteResults:     5 * time.Minute,
+		EndpointStockView:        5 * time.Minute,
+		EndpointChart:            12 * time.Hour,
+		EndpointIntraday:         30 * time.Second,
+		EndpointEntitlements:     10 * time.Minute,
+		EndpointFinancialReports: 10 * time.Minute,
+	}
+}
+
+// Cache is the storage of the cached responses.
+// Key is made of request method, url and form body.
+type Cache interface {
+	// Get is to get the cached value, ok is false when not found or expired.
+	Get(key string) (value []byte, ok bool)
+	// Set is to save the value for the ttl duration.
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// WithCache is the option to cache the successful responses,
+// ttl is the cache duration of every endpoint, endpoint not in ttl is not cached.
+// example : WithCache(NewMemoryCache(1000), DefaultCacheTTL())
+func WithCache(cache Cache, ttl map[Endpoint]time.Duration) ClientOption {
+	return func(c *Client) {
+		c.cache = cache
+		c.cacheTTL = ttl
+	}
+}
+
+// cacheKey is the cache key of the request.
+func cacheKey(method, url string, payload []byte) string {
+	return method + " " + url + "\n" + string(payload)
+}
+
+// getCache is to get the cached response of the request.
+func (c *Client) getCache(key, path string) (*http.Response, bool) {
+	if c.cache == nil || c.cacheTTL[endpointOf(path)] <= 0 {
+		return nil, false
+	}
+	value, ok := c.cache.Get(key)
+	if !ok {
+		return nil, false
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(value)), nil)
+	if err != nil {
+		return nil, false
+	}
+	return resp, true
+}
+
+// setCache is to save the response to cache,
+// the response body is read and replaced.
+func (c *Client) setCache(key, path string, resp *http.Response) error {
+	ttl := c.cacheTTL[endpointOf(path)]
+	if c.cache == nil || ttl <= 0 {
+		return nil
+	}
+	value, err := httputil.DumpResponse(resp, true)
+	if err != nil {
+		return err
+	}
+	c.cache.Set(key, value, ttl)
+	return nil
+}
+
+// MemoryCache is the in-memory least recently used cache.
+type MemoryCache struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[string]*list.Element
+	order    *list.List // front is the most recently used
+	clock    func() time.Time
+}
+
+// MemoryCacheOption is the option of MemoryCache.
+type MemoryCacheOption func(*MemoryCache)
+
+// WithCacheClock is the option to set the clock of MemoryCache to expire
+// the responses, eg the fake clock in tests. It is time.Now by default.
+func WithCacheClock(now func() time.Time) MemoryCacheOption {
+	return func(m *MemoryCache) {
+		if now == nil {
+			now = time.Now
+		}
+		m.clock = now
+	}
+}
+
+// memoryCacheItem is the item of MemoryCache.
+type memoryCacheItem struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryCache is to initialise MemoryCache keeps at most capacity responses,
+// capacity 0 or less is unlimited.
+func NewMemoryCache(capacity int, opts ...MemoryCacheOption) *MemoryCache {
+	m := &MemoryCache{
+		capacity: capacity,
+		items:    map[string]*list.Element{},
+		order:    list.New(),
+		clock:    time.Now,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Get is to implement Cache interface.
+func (m *MemoryCache) Get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	element, ok := m.items[key]
+	if !ok {
+		return nil, false
+	}
+	item := element.Value.(*memoryCacheItem)
+	if m.clock().After(item.expires) {
+		m.order.Remove(element)
+		delete(m.items, key)
+		return nil, false
+	}
+	m.order.MoveToFront(element)
+	return item.value, true
+}
+
+// Set is to implement Cache interface.
+func (m *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	item := &memoryCacheItem{key: key, value: value, expires: m.clock().Add(ttl)}
+	if element, ok := m.items[key]; ok {
+		element.Value = item
+		m.order.MoveToFront(element)
+		return
+	}
+	m.items[key] = m.order.PushFront(item)
+	if m.capacity > 0 && m.order.Len() > m.capacity {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.items, oldest.Value.(*memoryCacheItem).key)
+	}
+}
+
+// Len is the number of cached responses, including the expired.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
+
+// FileCache is the file system cache, every response is saved
+// as a file in the directory named by the hash of the key.
+// The write errors are ignored, the response is just not cached.
+type FileCache struct {
+	dir string
+}
+
+// NewFileCache is to initialise FileCache in the directory,
+// the directory is created if not exist.
+func NewFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileCache{dir: dir}, nil
+}
+
+// path is the file path of the key.
+func (f *FileCache) path(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return filepath.Join(f.dir, hex.EncodeToString(hash[:]))
+}
+
+// Get is to implement Cache interface.
+// The file is the expiry time in unix nano at first line, then the value.
+func (f *FileCache) Get(key string) ([]byte, bool) {
+	b, err := ioutil.ReadFile(f.path(key))
+	if err != nil {
+		return nil, false
+	}
+	i := bytes.IndexByte(b, '\n')
+	if i < 0 {
+		return nil, false
+	}
+	expires, err := strconv.ParseInt(string(b[:i]), 10, 64)
+	if err != nil || time.Now().UnixNano() > expires {
+		os.Remove(f.path(key))
+		return nil, false
+	}
+	return b[i+1:], true
+}
+
+// Set is to implement Cache interface.
+func (f *FileCache) Set(key string, value []byte, ttl time.Duration) {
+	expires := strconv.FormatInt(time.Now().Add(ttl).UnixNano(), 10)
+	tmp, err := ioutil.TempFile(f.dir, ".tmp-")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(append([]byte(expires+"\n"), value...))
+	if closeErr := tmp.Close(); err != nil || closeErr != nil {
+		return
+	}
+	// rename is atomic, the readers won't get the half written file.
+	os.Rename(tmp.Name(), f.path(key))
+}
@@ -0,0 +1,131 @@
+package klse_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	klse "github.com/kokweikhong/klsescreener-scraper"
+)
+
+// cachePage is the page of the quote results with the request body as the
+// title and the chart data, the requests are counted by the test server.
+func cachePage(r *http.Request) string {
+	body, _ := ioutil.ReadAll(r.Body)
+	return `<html><body><table>` + quoteResultsHead + `<tbody><tr class="list"><td title="` + string(body) + `">A</td></tr></tbody></table>` +
+		chartPage(`[1656288000000,1,2,0.5,1.5,100],`) + `</body></html>`
+}
+
+func TestMemoryCache(t *testing.T) {
+	server := newTestServer(cachePage)
+	defer server.Close()
+
+	now := time.Date(2022, time.July, 8, 9, 0, 0, 0, klse.MYT)
+	cache := klse.NewMemoryCache(10, klse.WithCacheClock(func() time.Time { return now }))
+	client := klse.NewClient(
+		klse.WithBaseURL(server.URL),
+		klse.WithCache(cache, map[klse.Endpoint]time.Duration{
+			klse.EndpointMarkets: 30 * time.Second,
+			klse.EndpointChart:   time.Hour,
+		}),
+	)
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetMarketInformation(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.GetStockHistoricalData("0001"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if server.Requests() != 2 {
+		t.Errorf("requests = %d, want 2", server.Requests())
+	}
+
+	// markets is expired, chart is still cached.
+	now = now.Add(31 * time.Second)
+	client.GetMarketInformation()
+	client.GetStockHistoricalData("0001")
+	if server.Requests() != 3 {
+		t.Errorf("requests = %d, want 3", server.Requests())
+	}
+
+	// endpoint without ttl is not cached.
+	client.GetCompanyOverview("0001")
+	client.GetCompanyOverview("0001")
+	if server.Requests() != 5 {
+		t.Errorf("requests = %d, want 5", server.Requests())
+	}
+}
+
+func TestCacheQuoteResultsForm(t *testing.T) {
+	server := newTestServer(cachePage)
+	defer server.Close()
+
+	client := klse.NewClient(
+		klse.WithBaseURL(server.URL),
+		klse.WithCache(klse.NewMemoryCache(10), klse.DefaultCacheTTL()),
+	)
+	quote := client.NewQuoteResultRequest()
+	for i := 0; i < 2; i++ {
+		minPE, _ := quote.GetQuoteResults(quote.WithMinPE(1))
+		maxPE, _ := quote.GetQuoteResults(quote.WithMaxPE(1))
+		if len(minPE) != 1 || len(maxPE) != 1 || minPE[0].Name == maxPE[0].Name {
+			t.Fatalf("quote results with different form : %v, %v", minPE, maxPE)
+		}
+	}
+	if server.Requests() != 2 {
+		t.Errorf("requests = %d, want 2", server.Requests())
+	}
+}
+
+func TestMemoryCacheEviction(t *testing.T) {
+	cache := klse.NewMemoryCache(2)
+	cache.Set("a", []byte("a"), time.Hour)
+	cache.Set("b", []byte("b"), time.Hour)
+	cache.Get("a")
+	cache.Set("c", []byte("c"), time.Hour)
+	if _, ok := cache.Get("b"); ok {
+		t.Error("least recently used b is not evicted")
+	}
+	if _, ok := cache.Get("a"); !ok {
+		t.Error("recently used a is evicted")
+	}
+	if cache.Len() != 2 {
+		t.Errorf("len = %d, want 2", cache.Len())
+	}
+}
+
+func TestFileCache(t *testing.T) {
+	server := newTestServer(cachePage)
+	defer server.Close()
+
+	dir := t.TempDir()
+	for i := 0; i < 2; i++ {
+		// new client and cache, the responses are from the files.
+		cache, err := klse.NewFileCache(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		client := klse.NewClient(
+			klse.WithBaseURL(server.URL),
+			klse.WithCache(cache, klse.DefaultCacheTTL()),
+		)
+		data, err := client.GetStockHistoricalData("0001")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(data) != 1 || data[0].Close != 1.5 {
+			t.Errorf("data = %v, want 1 cached bar", data)
+		}
+	}
+	if server.Requests() != 1 {
+		t.Errorf("requests = %d, want 1", server.Requests())
+	}
+
+	cache, _ := klse.NewFileCache(dir)
+	cache.Set("expired", []byte("value"), -time.Second)
+	if _, ok := cache.Get("expired"); ok {
+		t.Error("expired value is returned")
+	}
+}
@@ -0,0 +1,280 @@
+// Package calendar is the trading calendar of Bursa Malaysia to check the
+// trading days and find the missing trading sessions of the historical data.
+//
+// The market is closed on weekends and the public holidays of Kuala Lumpur.
+// The holidays of the fixed dates and the Agong's birthday since 2013 are
+// built in, the holidays of the lunar and Islamic calendars, eg Chinese New
+// Year and Hari Raya, and the special holidays have to be configured with
+// WithHolidays every year.
+package calendar
+
+import (
+	"sort"
+	"time"
+
+	klse "github.com/kokweikhong/klsescreener-scraper"
+)
+
+// Holiday is the public holiday which Bursa Malaysia is closed.
+type Holiday struct {
+	Date klse.TradingDate
+	Name string
+}
+
+// Option is the option of the calendar.
+type Option func(*Calendar)
+
+// WithHolidays is to close the market on the holidays, eg Hari Raya
+// Aidilfitri, the replacement holiday has to be added as another holiday.
+func WithHolidays(holidays ...Holiday) Option {
+	return func(c *Calendar) {
+		for _, holiday := range holidays {
+			c.holidays[holiday.Date] = holiday.Name
+		}
+	}
+}
+
+// WithoutFixedHolidays is to not close the market on the built-in holidays,
+// only the weekends and the configured holidays are closed.
+func WithoutFixedHolidays() Option {
+	return func(c *Calendar) {
+		c.fixed = false
+	}
+}
+
+// Calendar is the trading calendar of Bursa Malaysia,
+// it is safe for concurrent use after initialised.
+type Calendar struct {
+	holidays map[klse.TradingDate]string
+	fixed    bool // the built-in holidays are closed.
+}
+
+// Default is the calendar with the weekends and the built-in holidays only.
+var Default = New()
+
+// New is to initialise the calendar of the weekends, the built-in holidays
+// and the holidays of the options.
+func New(opts ...Option) *Calendar {
+	c := &Calendar{holidays: map[klse.TradingDate]string{}, fixed: true}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// agongBirthday is the name of the birthday of the Yang di-Pertuan Agong.
+const agongBirthday = "Birthday of the Yang di-Pertuan Agong"
+
+// yearlyHolidays is the built-in holidays of the years which dates are not
+// regular. The birthday of the Yang di-Pertuan Agong is the first Saturday
+// of June until 2016, the birthday of the reigning Agong in 2017 to 2019 and
+// 8 June in 2020, it is the first Monday of June of the years after 2020
+// which are not in the table.
+var yearlyHolidays = map[int][]Holiday{
+	2013: {{Date: klse.TradingDate{Year: 2013, Month: time.June, Day: 1}, Name: agongBirthday}},
+	2014: {{Date: klse.TradingDate{Year: 2014, Month: time.June, Day: 7}, Name: agongBirthday}},
+	2015: {{Date: klse.TradingDate{Year: 2015, Month: time.June, Day: 6}, Name: agongBirthday}},
+	2016: {{Date: klse.TradingDate{Year: 2016, Month: time.June, Day: 4}, Name: agongBirthday}},
+	2017: {{Date: klse.TradingDate{Year: 2017, Month: time.September, Day: 9}, Name: agongBirthday}},
+	2018: {{Date: klse.TradingDate{Year: 2018, Month: time.September, Day: 9}, Name: agongBirthday}},
+	2019: {{Date: klse.TradingDate{Year: 2019, Month: time.July, Day: 30}, Name: agongBirthday}},
+	2020: {{Date: klse.TradingDate{Year: 2020, Month: time.June, Day: 8}, Name: agongBirthday}},
+}
+
+// fixedHoliday is the name of the built-in holiday, eg Labour Day.
+// The holiday on Sunday is replaced by Monday as in Kuala Lumpur.
+func fixedHoliday(date klse.TradingDate) (string, bool) {
+	if name, ok := builtinHoliday(date); ok {
+		return name, true
+	}
+	if date.Time().Weekday() == time.Monday {
+		if name, ok := builtinHoliday(date.AddDays(-1)); ok {
+			return name + " (replacement)", true
+		}
+	}
+	return "", false
+}
+
+// builtinHoliday is the name of the holiday of the fixed date or of the
+// yearly holidays on the date.
+func builtinHoliday(date klse.TradingDate) (string, bool) {
+	holidays, ok := yearlyHolidays[date.Year]
+	if !ok && date.Year > 2020 {
+		holidays = []Holiday{{Date: firstMondayOfJune(date.Year), Name: agongBirthday}}
+	}
+	for _, holiday := range holidays {
+		if holiday.Date == date {
+			return holiday.Name, true
+		}
+	}
+	return fixedDateHoliday(date)
+}
+
+// firstMondayOfJune is the first Monday of June of the year.
+func firstMondayOfJune(year int) klse.TradingDate {
+	date := klse.TradingDate{Year: year, Month: time.June, Day: 1}
+	return date.AddDays((int(time.Monday) - int(date.Time().Weekday()) + 7) % 7)
+}
+
+// fixedDateHoliday is the name of the holiday on the month and day.
+func fixedDateHoliday(date klse.TradingDate) (string, bool) {
+	switch {
+	case date.Month == time.January && date.Day == 1:
+		return "New Year's Day", true
+	case date.Month == time.February && date.Day == 1:
+		return "Federal Territory Day", true
+	case date.Month == time.May && date.Day == 1:
+		return "Labour Day", true
+	case date.Month == time.August && date.Day == 31:
+		return "National Day", true
+	case date.Month == time.September && date.Day == 16:
+		return "Malaysia Day", true
+	case date.Month == time.December && date.Day == 25:
+		return "Christmas Day", true
+	}
+	return "", false
+}
+
+// Holiday is to get the name of the holiday on the date, ok is false when
+// the date is not a holiday. The weekends are not holidays.
+func (c *Calendar) Holiday(date klse.TradingDate) (name string, ok bool) {
+	if name, ok := c.holidays[date]; ok {
+		return name, true
+	}
+	if c.fixed {
+		return fixedHoliday(date)
+	}
+	return "", false
+}
+
+// IsTradingDay is to check the market is open on the date.
+func (c *Calendar) IsTradingDay(date klse.TradingDate) bool {
+	switch date.Time().Weekday() {
+	case time.Saturday, time.Sunday:
+		return false
+	}
+	_, holiday := c.Holiday(date)
+	return !holiday
+}
+
+// NextTradingDay is the first trading day after the date.
+func (c *Calendar) NextTradingDay(date klse.TradingDate) klse.TradingDate {
+	for date = date.AddDays(1); !c.IsTradingDay(date); date = date.AddDays(1) {
+	}
+	return date
+}
+
+// PreviousTradingDay is the last trading day before the date.
+func (c *Calendar) PreviousTradingDay(date klse.TradingDate) klse.TradingDate {
+	for date = date.AddDays(-1); !c.IsTradingDay(date); date = date.AddDays(-1) {
+	}
+	return date
+}
+
+// TradingDays is the trading days from the date to the date, both inclusive.
+func (c *Calendar) TradingDays(from, to klse.TradingDate) []klse.TradingDate {
+	days := []klse.TradingDate{}
+	for date := from; !date.After(to); date = date.AddDays(1) {
+		if c.IsTradingDay(date) {
+			days = append(days, date)
+		}
+	}
+	return days
+}
+
+// Gap is the missing trading sessions of the historical data of a code.
+type Gap struct {
+	Code string
+	// Missing is the trading days without bar between the first and the last
+	// bar, eg the suspension of the stock.
+	Missing []klse.TradingDate
+	// Unexpected is the days with bar which are not trading days, eg the
+	// special holiday not configured in the calendar.
+	Unexpected []klse.TradingDate
+}
+
+// Sessions is to group the consecutive missing trading days, eg the
+// suspension from 2022-07-04 to 2022-07-06 is one group of three days.
+func (g *Gap) Sessions(c *Calendar) [][]klse.TradingDate {
+	sessions := [][]klse.TradingDate{}
+	for i, date := range g.Missing {
+		last := len(sessions) - 1
+		if i == 0 || c.NextTradingDay(g.Missing[i-1]) != date {
+			sessions = append(sessions, []klse.TradingDate{date})
+			continue
+		}
+		sessions[last] = append(sessions[last], date)
+	}
+	return sessions
+}
+
+// Gaps is to find the missing trading sessions of the bars of the code, the
+// bars don't need to be sorted.
+func (c *Calendar) Gaps(code string, bars []*klse.OHLC) *Gap {
+	gap := &Gap{Code: code, Missing: []klse.TradingDate{}, Unexpected: []klse.TradingDate{}}
+	if len(bars) == 0 {
+		return gap
+	}
+	dates := map[klse.TradingDate]bool{}
+	first, last := bars[0].TradingDate(), bars[0].TradingDate()
+	for _, bar := range bars {
+		date := bar.TradingDate()
+		dates[date] = true
+		if date.Before(first) {
+			first = date
+		}
+		if date.After(last) {
+			last = date
+		}
+	}
+	for _, date := range c.TradingDays(first, last) {
+		if !dates[date] {
+			gap.Missing = append(gap.Missing, date)
+		}
+		delete(dates, date)
+	}
+	for date := range dates {
+		gap.Unexpected = append(gap.Unexpected, date)
+	}
+	sort.Slice(gap.Unexpected, func(i, j int) bool {
+		return gap.Unexpected[i].Before(gap.Unexpected[j])
+	})
+	return gap
+}
+
+// GapReport is to find the missing trading sessions of the historical data
+// of every code, only the codes with missing or unexpected days are
+// returned and sorted by code.
+func (c *Calendar) GapReport(series map[string][]*klse.OHLC) []*Gap {
+	report := []*Gap{}
+	for code, bars := range series {
+		if gap := c.Gaps(code, bars); len(gap.Missing) > 0 || len(gap.Unexpected) > 0 {
+			report = append(report, gap)
+		}
+	}
+	sort.Slice(report, func(i, j int) bool {
+		return report[i].Code < report[j].Code
+	})
+	return report
+}
+
+// IsTradingDay is to check the market is open on the date with the default calendar.
+func IsTradingDay(date klse.TradingDate) bool {
+	return Default.IsTradingDay(date)
+}
+
+// NextTradingDay is the first trading day after the date with the default calendar.
+func NextTradingDay(date klse.TradingDate) klse.TradingDate {
+	return Default.NextTradingDay(date)
+}
+
+// PreviousTradingDay is the last trading day before the date with the default calendar.
+func PreviousTradingDay(date klse.TradingDate) klse.TradingDate {
+	return Default.PreviousTradingDay(date)
+}
+
+// GapReport is to find the missing trading sessions of the historical data
+// of every code with the default calendar.
+func GapReport(series map[string][]*klse.OHLC) []*Gap {
+	return Default.GapReport(series)
+}
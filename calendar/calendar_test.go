@@ -0,0 +1,164 @@
+package calendar_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	klse "github.com/kokweikhong/klsescreener-scraper"
+	"github.com/kokweikhong/klsescreener-scraper/calendar"
+	"github.com/kokweikhong/klsescreener-scraper/internal/fixture"
+)
+
+// date is the trading date of the "2006-01-02".
+func date(t *testing.T, s string) klse.TradingDate {
+	t.Helper()
+	d, err := klse.ParseTradingDate(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+// bars is the bars of the dates in "2006-01-02".
+func bars(t *testing.T, dates ...string) []*klse.OHLC {
+	bars := []*klse.OHLC{}
+	for _, s := range dates {
+		bars = append(bars, &klse.OHLC{Date: date(t, s).Time(), Close: 1})
+	}
+	return bars
+}
+
+// hariRaya2022 is the holidays of Hari Raya Aidilfitri 2022, the replacement
+// of Labour Day on 2022-05-02 is built in.
+var hariRaya2022 = calendar.WithHolidays(
+	calendar.Holiday{Date: klse.TradingDate{Year: 2022, Month: time.May, Day: 3}, Name: "Hari Raya Aidilfitri"},
+	calendar.Holiday{Date: klse.TradingDate{Year: 2022, Month: time.May, Day: 4}, Name: "Hari Raya Aidilfitri (replacement)"},
+)
+
+func TestIsTradingDay(t *testing.T) {
+	c := calendar.New(hariRaya2022)
+	tests := []struct {
+		date string
+		want bool
+	}{
+		{"2022-07-08", true},  // Friday
+		{"2022-07-09", false}, // Saturday
+		{"2022-07-10", false}, // Sunday
+		{"2022-05-01", false}, // Labour Day on Sunday
+		{"2022-05-02", false}, // Labour Day replacement
+		{"2022-05-03", false}, // Hari Raya Aidilfitri
+		{"2022-05-05", true},
+		{"2022-06-06", false}, // Agong's birthday, the first Monday of June
+		{"2022-06-13", true},
+		{"2020-06-01", true},  // first Monday of June 2020
+		{"2020-06-08", false}, // Agong's birthday 2020
+		{"2019-06-03", true},  // first Monday of June 2019
+		{"2019-07-30", false}, // Agong's birthday 2019
+		{"2018-09-10", false}, // Agong's birthday 2018 replacement
+		{"2024-06-03", false}, // Agong's birthday, the first Monday of June
+		{"2026-06-01", false}, // Agong's birthday, the first Monday of June
+		{"2030-06-03", false}, // Agong's birthday, the first Monday of June
+		{"2030-06-10", true},
+		{"2023-02-01", false}, // Federal Territory Day
+		{"2026-02-02", false}, // Federal Territory Day replacement
+		{"2022-08-31", false}, // National Day
+		{"2022-09-16", false}, // Malaysia Day
+		{"2022-12-26", false}, // Christmas Day replacement
+		{"2023-01-02", false}, // New Year's Day replacement
+		{"2021-01-01", false}, // New Year's Day
+		{"2022-12-27", true},
+	}
+	for _, tt := range tests {
+		if got := c.IsTradingDay(date(t, tt.date)); got != tt.want {
+			t.Errorf("IsTradingDay(%s) = %v, want %v", tt.date, got, tt.want)
+		}
+	}
+
+	if name, ok := c.Holiday(date(t, "2022-12-26")); !ok || name != "Christmas Day (replacement)" {
+		t.Errorf("holiday on 2022-12-26 = %q, %v", name, ok)
+	}
+	if name, ok := c.Holiday(date(t, "2018-09-10")); !ok || name != "Birthday of the Yang di-Pertuan Agong (replacement)" {
+		t.Errorf("holiday on 2018-09-10 = %q, %v", name, ok)
+	}
+	if _, ok := c.Holiday(date(t, "2022-07-09")); ok {
+		t.Error("Saturday is a holiday")
+	}
+	if calendar.New(calendar.WithoutFixedHolidays()).IsTradingDay(date(t, "2022-08-31")) != true {
+		t.Error("National Day is closed without the fixed holidays")
+	}
+}
+
+func TestNextTradingDay(t *testing.T) {
+	c := calendar.New(hariRaya2022)
+	tests := []struct {
+		date, next, previous string
+	}{
+		{"2022-07-08", "2022-07-11", "2022-07-07"},
+		{"2022-07-09", "2022-07-11", "2022-07-08"},
+		{"2022-04-29", "2022-05-05", "2022-04-28"},
+		{"2022-05-05", "2022-05-06", "2022-04-29"},
+	}
+	for _, tt := range tests {
+		if got := c.NextTradingDay(date(t, tt.date)).String(); got != tt.next {
+			t.Errorf("NextTradingDay(%s) = %s, want %s", tt.date, got, tt.next)
+		}
+		if got := c.PreviousTradingDay(date(t, tt.date)).String(); got != tt.previous {
+			t.Errorf("PreviousTradingDay(%s) = %s, want %s", tt.date, got, tt.previous)
+		}
+	}
+	if got := calendar.NextTradingDay(date(t, "2022-08-30")).String(); got != "2022-09-01" {
+		t.Errorf("default NextTradingDay = %s", got)
+	}
+	if got := len(c.TradingDays(date(t, "2022-05-01"), date(t, "2022-05-31"))); got != 19 {
+		t.Errorf("trading days of May 2022 = %d, want 19", got)
+	}
+}
+
+func TestGapReport(t *testing.T) {
+	c := calendar.New(hariRaya2022)
+	report := c.GapReport(map[string][]*klse.OHLC{
+		"0001": bars(t, "2022-07-04", "2022-07-05", "2022-07-06", "2022-07-07", "2022-07-08"),
+		// suspended from 07-05 to 07-06 and on 07-08, traded on Saturday.
+		"0002": bars(t, "2022-07-11", "2022-07-07", "2022-07-04", "2022-07-09"),
+		"0003": nil,
+	})
+	if len(report) != 1 || report[0].Code != "0002" {
+		t.Fatalf("report = %v", report)
+	}
+	gap := report[0]
+	if got := fmt.Sprint(gap.Missing); got != "[2022-07-05 2022-07-06 2022-07-08]" {
+		t.Errorf("missing = %s", got)
+	}
+	if got := fmt.Sprint(gap.Unexpected); got != "[2022-07-09]" {
+		t.Errorf("unexpected = %s", got)
+	}
+	if got := fmt.Sprint(gap.Sessions(c)); got != "[[2022-07-05 2022-07-06] [2022-07-08]]" {
+		t.Errorf("sessions = %s", got)
+	}
+}
+
+func TestGapReportOfHistoricalData(t *testing.T) {
+	client := fixture.NewClient("../testdata/fixtures")
+	data, err := client.GetStockHistoricalData("7251")
+	if err != nil {
+		t.Fatal(err)
+	}
+	series := map[string][]*klse.OHLC{"7251": data}
+
+	// Wesak Day on 2022-05-15 is replaced by Monday and not configured.
+	report := calendar.New(hariRaya2022).GapReport(series)
+	if len(report) != 1 || fmt.Sprint(report[0].Missing) != "[2022-05-16]" {
+		t.Fatalf("report = %v", report)
+	}
+	// the fixture has the bars on the fixed holidays 2022-05-02 and 2022-06-06.
+	if got := fmt.Sprint(report[0].Unexpected); got != "[2022-05-02 2022-06-06]" {
+		t.Errorf("unexpected = %s", got)
+	}
+
+	wesak := calendar.Holiday{Date: date(t, "2022-05-16"), Name: "Wesak Day (replacement)"}
+	c := calendar.New(calendar.WithoutFixedHolidays(), hariRaya2022, calendar.WithHolidays(wesak))
+	if report := c.GapReport(series); len(report) != 0 {
+		t.Errorf("report = %v", report)
+	}
+}
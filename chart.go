@@ -0,0 +1,513 @@
+package klse
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SkippedRowsError is the error for the rows of the chart which can't be
+// parsed or have no price, eg null close, the other rows are still returned
+// with the error. The rows with the other missing values, eg null volume,
+// are returned with the fields as zero value and the issues in Missing.
+// errors.Is(err, ErrSkippedRows) is always true and errors.Is(err, ErrNoValue)
+// is true when Missing is not empty.
+type SkippedRowsError struct {
+	URL     string
+	Rows    ParseReport // issues of the skipped rows, eg "data[3].date", "data[5].close".
+	Missing ParseReport // missing values of the returned rows, eg "data[4].volume".
+}
+
+// Error is to implement error interface.
+func (e *SkippedRowsError) Error() string {
+	issues := append(append(ParseReport{}, e.Rows...), e.Missing...)
+	return fmt.Sprintf("%s: %s : %d rows skipped, %d values missing : %s",
+		ErrSkippedRows, e.URL, rowsOf(e.Rows), len(e.Missing), issues.Err())
+}
+
+// Is is to match the error with ErrSkippedRows, and ErrNoValue when some
+// values are missing.
+func (e *SkippedRowsError) Is(target error) bool {
+	return target == ErrSkippedRows || target == ErrNoValue && len(e.Missing) > 0
+}
+
+// rowsOf is to count the rows of the issues, eg "data[3].open" and
+// "data[3].close" are of the same row.
+func rowsOf(report ParseReport) int {
+	rows := map[string]bool{}
+	for _, issue := range report {
+		rows[strings.SplitN(issue.Field, ".", 2)[0]] = true
+	}
+	return len(rows)
+}
+
+// chartTokenKind is the kind of the javascript token of the chart page.
+type chartTokenKind int
+
+const (
+	chartEOF    chartTokenKind = iota
+	chartPunct                 // eg "[", "]", ",", "=", "==".
+	chartIdent                 // eg data, null, undefined.
+	chartNumber                // eg 1651449600000, -0.25, 1e-3.
+	chartString                // text of the quoted string without the quotes.
+)
+
+// chartToken is the javascript token of the chart page.
+type chartToken struct {
+	kind chartTokenKind
+	text string
+}
+
+// is is to check the token is the punctuator or identifier of the text.
+func (t chartToken) is(kind chartTokenKind, text string) bool {
+	return t.kind == kind && t.text == text
+}
+
+// chartLexer is to split the javascript of the <script> elements of the chart
+// page into tokens, the HTML outside the scripts, the spaces and comments are
+// skipped. It is not a full javascript lexer, eg the regular expression
+// literal is read as punctuators and identifiers.
+type chartLexer struct {
+	r        *bufio.Reader
+	peeked   *chartToken
+	inScript bool
+}
+
+// unread is to return the token to be read by the next call of next.
+func (l *chartLexer) unread(t chartToken) {
+	l.peeked = &t
+}
+
+// next is to read the next token, the kind is chartEOF at the end of the page.
+func (l *chartLexer) next() (chartToken, error) {
+	if l.peeked != nil {
+		t := *l.peeked
+		l.peeked = nil
+		return t, nil
+	}
+	var b byte
+	for {
+		err := l.skipHTML()
+		if err == nil {
+			b, err = l.skipSpaces()
+		}
+		if err == io.EOF {
+			return chartToken{kind: chartEOF}, nil
+		}
+		if err != nil {
+			return chartToken{}, err
+		}
+		if b != '<' || !l.peekTag("/script") {
+			break
+		}
+		l.inScript = false
+	}
+	next, _ := l.r.Peek(1)
+	switch {
+	case b == '"' || b == '\'' || b == '`':
+		text, err := l.readString(b)
+		return chartToken{kind: chartString, text: text}, err
+	case isDigitByte(b), (b == '.' || b == '-' || b == '+') && len(next) == 1 && (isDigitByte(next[0]) || next[0] == '.'):
+		return chartToken{kind: chartNumber, text: l.readNumber(b)}, nil
+	case isIdentifierByte(b):
+		return chartToken{kind: chartIdent, text: l.readIdentifier(b)}, nil
+	case b == '=' && len(next) == 1 && (next[0] == '=' || next[0] == '>'):
+		l.r.ReadByte()
+		return chartToken{kind: chartPunct, text: "=" + string(next)}, nil
+	}
+	return chartToken{kind: chartPunct, text: string(b)}, nil
+}
+
+// skipHTML is to skip the text, tags and comments of the page until the body
+// of the next <script> element, so the quotes in the text eg <title>D'NONCE
+// are not read as javascript strings.
+func (l *chartLexer) skipHTML() error {
+	for !l.inScript {
+		b, err := l.r.ReadByte()
+		if err != nil {
+			return err
+		}
+		switch {
+		case b != '<':
+			continue
+		case l.peekTag("!--"):
+			if err := l.skipUntil("-->"); err != nil {
+				return err
+			}
+		case l.peekTag("script"):
+			if err := l.skipUntil(">"); err != nil {
+				return err
+			}
+			l.inScript = true
+		}
+	}
+	return nil
+}
+
+// peekTag is to check the bytes after "<" are the tag name in any case, the
+// tag is read without its attributes and ">" when it is matched.
+func (l *chartLexer) peekTag(name string) bool {
+	next, _ := l.r.Peek(len(name) + 1)
+	if len(next) < len(name) || !strings.EqualFold(string(next[:len(name)]), name) {
+		return false
+	}
+	if name != "!--" && len(next) > len(name) && isIdentifierByte(next[len(name)]) {
+		return false // eg <scripts>.
+	}
+	l.r.Discard(len(name))
+	return true
+}
+
+// skipUntil is to skip the page until the end of the text, eg "-->".
+func (l *chartLexer) skipUntil(end string) error {
+	matched := 0
+	for matched < len(end) {
+		b, err := l.r.ReadByte()
+		if err != nil {
+			return err
+		}
+		switch {
+		case b == end[matched]:
+			matched++
+		case b == end[0]:
+			matched = 1
+		default:
+			matched = 0
+		}
+	}
+	return nil
+}
+
+// skipSpaces is to read the next byte which is not space or in comment.
+func (l *chartLexer) skipSpaces() (byte, error) {
+	for {
+		b, err := l.r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if isSpaceByte(b) {
+			continue
+		}
+		if b != '/' {
+			return b, nil
+		}
+		next, _ := l.r.Peek(1)
+		switch {
+		case len(next) == 1 && next[0] == '/':
+			if _, err := l.r.ReadString('\n'); err != nil {
+				return 0, err
+			}
+		case len(next) == 1 && next[0] == '*':
+			l.r.ReadByte()
+			if err := l.skipBlockComment(); err != nil {
+				return 0, err
+			}
+		default:
+			return b, nil
+		}
+	}
+}
+
+// skipBlockComment is to skip until the end of the comment "*/".
+func (l *chartLexer) skipBlockComment() error {
+	star := false
+	for {
+		b, err := l.r.ReadByte()
+		if err != nil {
+			return err
+		}
+		if star && b == '/' {
+			return nil
+		}
+		star = b == '*'
+	}
+}
+
+// readString is to read the string until the quote, the escaped characters
+// are kept as they are.
+func (l *chartLexer) readString(quote byte) (string, error) {
+	text := []byte{}
+	for {
+		b, err := l.r.ReadByte()
+		if err == io.EOF {
+			return "", io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return "", err
+		}
+		switch b {
+		case quote:
+			return string(text), nil
+		case '\\':
+			escaped, err := l.r.ReadByte()
+			if err != nil {
+				return "", io.ErrUnexpectedEOF
+			}
+			text = append(text, b, escaped)
+			continue
+		}
+		text = append(text, b)
+	}
+}
+
+// readNumber is to read the number starting with the byte, eg -1.5e-3.
+func (l *chartLexer) readNumber(first byte) string {
+	text := []byte{first}
+	for {
+		next, _ := l.r.Peek(1)
+		if len(next) == 0 {
+			return string(text)
+		}
+		b, last := next[0], text[len(text)-1]
+		if !isDigitByte(b) && b != '.' && b != 'e' && b != 'E' && !((b == '-' || b == '+') && (last == 'e' || last == 'E')) {
+			return string(text)
+		}
+		l.r.ReadByte()
+		text = append(text, b)
+	}
+}
+
+// readIdentifier is to read the identifier starting with the byte.
+func (l *chartLexer) readIdentifier(first byte) string {
+	text := []byte{first}
+	for {
+		next, _ := l.r.Peek(1)
+		if len(next) == 0 || !isIdentifierByte(next[0]) {
+			return string(text)
+		}
+		l.r.ReadByte()
+		text = append(text, next[0])
+	}
+}
+
+// isIdentifierByte is to check the byte is part of javascript identifier.
+func isIdentifierByte(b byte) bool {
+	return b == '_' || b == '$' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || isDigitByte(b)
+}
+
+// isDigitByte is to check the byte is 0-9.
+func isDigitByte(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// isSpaceByte is to check the byte is white space.
+func isSpaceByte(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == '\f' || b == '\v'
+}
+
+// chartReader is to read the rows of the javascript array "data = [...]"
+// of the chart page one by one without reading the whole page.
+type chartReader struct {
+	lexer *chartLexer
+}
+
+// newChartReader is to initialise the chart reader of the page.
+func newChartReader(r io.Reader) *chartReader {
+	return &chartReader{lexer: &chartLexer{r: bufio.NewReader(r)}}
+}
+
+// findData is to skip the page until the "[" of "data = [".
+// found is false when the page has no data array.
+func (c *chartReader) findData() (found bool, err error) {
+	for {
+		t, err := c.lexer.next()
+		if err != nil || t.kind == chartEOF {
+			return false, err
+		}
+		if !t.is(chartIdent, "data") {
+			continue
+		}
+		matched := 0
+		for _, punct := range []string{"=", "["} {
+			if t, err = c.lexer.next(); err != nil {
+				return false, err
+			}
+			if !t.is(chartPunct, punct) {
+				c.lexer.unread(t)
+				break
+			}
+			matched++
+		}
+		if matched == 2 {
+			return true, nil
+		}
+	}
+}
+
+// next is to read the values of the next row of the data array, ok is false
+// at the end of the array. The trailing commas are ignored, the null and
+// the holes of the row are empty values.
+func (c *chartReader) next() (values []string, ok bool, err error) {
+	for {
+		t, err := c.lexer.next()
+		switch {
+		case err != nil:
+			return nil, false, err
+		case t.kind == chartEOF:
+			return nil, false, io.ErrUnexpectedEOF
+		case t.is(chartPunct, ","):
+			continue
+		case t.is(chartPunct, "]"):
+			return nil, false, nil
+		case t.is(chartPunct, "["):
+			values, err := c.row()
+			return values, err == nil, err
+		}
+		value, err := c.value(t)
+		return []string{value}, err == nil, err
+	}
+}
+
+// row is to read the values of the row until "]".
+func (c *chartReader) row() ([]string, error) {
+	values := []string{}
+	expectValue := true
+	for {
+		t, err := c.lexer.next()
+		switch {
+		case err != nil:
+			return nil, err
+		case t.kind == chartEOF:
+			return nil, io.ErrUnexpectedEOF
+		case t.is(chartPunct, "]"):
+			return values, nil
+		case t.is(chartPunct, ","):
+			if expectValue {
+				values = append(values, "") // hole, eg [1,,2].
+			}
+			expectValue = true
+			continue
+		}
+		value, err := c.value(t)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		expectValue = false
+	}
+}
+
+// value is to get the text of the value token, null, undefined and NaN are
+// empty, the nested array or object is skipped and kept as its brackets.
+func (c *chartReader) value(t chartToken) (string, error) {
+	switch {
+	case t.kind == chartNumber, t.kind == chartString:
+		return t.text, nil
+	case t.kind == chartIdent:
+		switch t.text {
+		case "null", "undefined", "NaN":
+			return "", nil
+		}
+		return t.text, nil
+	case t.is(chartPunct, "["), t.is(chartPunct, "{"):
+		return t.text + "...", c.skipNested()
+	}
+	return "", fmt.Errorf("unexpected %q in chart data", t.text)
+}
+
+// skipNested is to skip the nested array or object after its "[" or "{".
+func (c *chartReader) skipNested() error {
+	for depth := 1; depth > 0; {
+		t, err := c.lexer.next()
+		switch {
+		case err != nil:
+			return err
+		case t.kind == chartEOF:
+			return io.ErrUnexpectedEOF
+		case t.is(chartPunct, "["), t.is(chartPunct, "{"):
+			depth++
+		case t.is(chartPunct, "]"), t.is(chartPunct, "}"):
+			depth--
+		}
+	}
+	return nil
+}
+
+// readChart is to parse the rows of the chart data with the columns, the
+// values after the columns eg adjusted close are ignored. The rows which
+// can't be parsed are skipped and returned as *SkippedRowsError with the
+// other rows. The rows without price eg null close are skipped too, so the
+// zero price doesn't enter the stores and the returns, the rows with the
+// other missing values eg null volume are kept and their fields are reported
+// in the error. The page without data array is ErrNoChartData.
+// The parsing stops when the context is cancelled.
+func readChart[T any](p *parser, r io.Reader, columns int, parse func(field string, values []string) T) ([]T, error) {
+	results := []T{}
+	var skipped, missing ParseReport
+	chart := newChartReader(r)
+	found, err := chart.findData()
+	if err != nil {
+		return nil, newParseError(p.url, err)
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrNoChartData, p.url)
+	}
+	for row := 0; ; row++ {
+		if err := p.ctx.Err(); err != nil {
+			return nil, err
+		}
+		values, ok, err := chart.next()
+		if err != nil {
+			return nil, newParseError(p.url, err)
+		}
+		if !ok {
+			break
+		}
+		field := elementField("data", row)
+		if len(values) < columns {
+			p.issue(strings.TrimSuffix(field, "."), strings.Join(values, ","),
+				fmt.Errorf("%d values, want %d", len(values), columns))
+			skipped = append(skipped, p.takeReport()...)
+			continue
+		}
+		result := parse(field, values)
+		report := p.takeReport()
+		if !onlyMissingVolume(report) {
+			skipped = append(skipped, report...)
+			continue
+		}
+		missing = append(missing, report...)
+		p.log(LogDebug, "getting historical data", Field{Key: "row", Value: row}, Field{Key: "data", Value: result})
+		results = append(results, result)
+	}
+	if len(skipped) > 0 || len(missing) > 0 {
+		return results, &SkippedRowsError{URL: p.url, Rows: skipped, Missing: missing}
+	}
+	return results, nil
+}
+
+// onlyMissingVolume is to check all the issues of the row are ErrNoValue of
+// the fields which are not price, eg "data[4].volume".
+func onlyMissingVolume(report ParseReport) bool {
+	for _, issue := range report {
+		if !errors.Is(issue, ErrNoValue) || isPriceField(issue.Field) {
+			return false
+		}
+	}
+	return true
+}
+
+// isPriceField is to check the field of the chart row is open, high, low
+// or close, eg "data[4].close".
+func isPriceField(field string) bool {
+	switch field[strings.LastIndex(field, ".")+1:] {
+	case "open", "high", "low", "close":
+		return true
+	}
+	return false
+}
+
+// chartDate is to convert the chart timestamp in milliseconds of the field
+// to the midnight MYT of the trading date.
+func (p *parser) chartDate(field, text string) time.Time {
+	msec, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		p.issue(field, text, err)
+		return time.Time{}
+	}
+	return tradingDateOfUnixMilli(msec).Time()
+}
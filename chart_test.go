@@ -0,0 +1,180 @@
+package klse_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	klse "github.com/kokweikhong/klsescreener-scraper"
+	"github.com/kokweikhong/klsescreener-scraper/keys"
+)
+
+// withinSecond is to fail the test when the function doesn't return in a second.
+func withinSecond(t *testing.T, f func()) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f()
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the fetcher hangs")
+	}
+}
+
+func TestChartSkippedRows(t *testing.T) {
+	server := chartPageServer(`[1656288000000,642.130,645.020,638.410,640.120,312450000],
+		[1656374400000,640.120,641.880],
+		[abc,640.120,641.880,636.050,637.930,298113000],
+		[1656460800000,637.930,639.500,633.270,x,301227400],
+		[1656547200000,635.610,638.990,634.100,638.400,287654100],`)
+	defer server.Close()
+	client := klse.NewClient(klse.WithBaseURL(server.URL), klse.WithChartRateLimit(0, 0))
+
+	fetchers := map[string]func() ([]*klse.OHLC, error){
+		"stock": func() ([]*klse.OHLC, error) { return client.GetStockHistoricalData("0020I") },
+		"bursa": func() ([]*klse.OHLC, error) { return client.GetBursaIndexHistoricalData(keys.PROPERTY) },
+	}
+	for name, fetch := range fetchers {
+		var data []*klse.OHLC
+		var err error
+		withinSecond(t, func() { data, err = fetch() })
+		var skipped *klse.SkippedRowsError
+		if !errors.As(err, &skipped) || !errors.Is(err, klse.ErrSkippedRows) {
+			t.Fatalf("%s err = %v, want *SkippedRowsError", name, err)
+		}
+		if len(skipped.Rows) != 3 || skipped.Rows.Valid("data[1]") || skipped.Rows.Valid("data[2].date") ||
+			skipped.Rows.Valid("data[3].close") {
+			t.Errorf("%s skipped = %v", name, skipped.Rows)
+		}
+		if len(data) != 2 || data[0].Close != 640.12 || data[1].Close != 638.4 {
+			t.Errorf("%s data = %d", name, len(data))
+		}
+	}
+}
+
+func TestMarketChartSkippedRows(t *testing.T) {
+	server := chartPageServer(`[1656288000000, 1822.8000, 1523],
+		[1656374400000, 1820.3000],
+		[1656460800000, 1817.5000, 987],`)
+	defer server.Close()
+	client := klse.NewClient(klse.WithBaseURL(server.URL))
+
+	var data []*klse.MarketHistoricalData
+	var err error
+	withinSecond(t, func() { data, err = client.GetMarketIndexHistoricalData(keys.GOLD) })
+	if !errors.Is(err, klse.ErrSkippedRows) || len(data) != 2 || data[1].Close != 1817.5 {
+		t.Errorf("data = %d, err = %v", len(data), err)
+	}
+}
+
+func TestChartTokenizer(t *testing.T) {
+	server := chartPageServer(`// the rows of the candlestick, adjusted close is the last value
+		[1656288000000, 642.130, 645.020, 638.410, 640.120, 312450000, 639.5,],
+		/* [1656374400000, 1, 1, 1, 1, 1], */
+		[1656374400000,640.120,641.880,636.050,null,298113000],
+		[1656460800000,637.930,639.500,633.270,635.610,301227400,{"note": "a]b"},],
+		[1656547200000,635.610,638.990,634.100,638.400,,],
+		,`)
+	defer server.Close()
+	client := klse.NewClient(klse.WithBaseURL(server.URL), klse.WithChartRateLimit(0, 0))
+
+	data, err := client.GetStockHistoricalData("0020I")
+	var skipped *klse.SkippedRowsError
+	if !errors.As(err, &skipped) {
+		t.Fatalf("err = %v, want *SkippedRowsError", err)
+	}
+	closeIssue, volumeIssue := skipped.Rows.Issue("data[1].close"), skipped.Missing.Issue("data[3].volume")
+	if len(skipped.Rows) != 1 || len(skipped.Missing) != 1 || closeIssue == nil || !errors.Is(closeIssue, klse.ErrNoValue) ||
+		volumeIssue == nil || !errors.Is(err, klse.ErrNoValue) {
+		t.Errorf("skipped = %v, missing = %v", skipped.Rows, skipped.Missing)
+	}
+	// the row with null close is skipped, the row with null volume is kept.
+	if len(data) != 3 || data[0].Close != 640.12 || data[0].Volume != 312450000 || data[1].Close != 635.61 ||
+		data[2].Close != 638.4 || data[2].Volume != 0 {
+		t.Errorf("data = %d", len(data))
+	}
+}
+
+func TestChartWithQuoteInText(t *testing.T) {
+	server := pageServer("<html><head><title>D'NONCE Chart</title></head><body><div id=\"chart\"></div>" +
+		"<SCRIPT type=\"text/javascript\">var data = [[1656288000000,1,2,0.5,1.5,100],];\n" +
+		"Highcharts.stockChart('chart', {series: [{data: data}]});</SCRIPT></body></html>")
+	defer server.Close()
+	client := klse.NewClient(klse.WithBaseURL(server.URL), klse.WithChartRateLimit(0, 0))
+
+	data, err := client.GetStockHistoricalData("7277")
+	if err != nil || len(data) != 1 || data[0].Close != 1.5 {
+		t.Fatalf("data = %v, err = %v", data, err)
+	}
+}
+
+func TestChartWithoutData(t *testing.T) {
+	pages := map[string]string{
+		"error page":     `<html><body><h1>Stock not found</h1></body></html>`,
+		"data in string": `<script>var s = "data = [[1,2]]"; var data2 = [];</script>`,
+		"data compared":  `<script>if (data == [1]) {}</script>`,
+		"quote in title": `<html><head><title>D'NONCE Chart</title></head><body><p>Stock not found</p></body></html>`,
+		"data in text":   `<html><body><p>var data = [[1656288000000,1,1,1,1,100]];</p><!-- <script> --></body></html>`,
+	}
+	for name, page := range pages {
+		server := pageServer(page)
+		client := klse.NewClient(klse.WithBaseURL(server.URL), klse.WithChartRateLimit(0, 0))
+		data, err := client.GetStockHistoricalData("0000")
+		if !errors.Is(err, klse.ErrNoChartData) || data != nil {
+			t.Errorf("%s: data = %v, err = %v", name, data, err)
+		}
+		server.Close()
+	}
+
+	server := pageServer(`<script>var data = [[1656288000000, 1, 1`)
+	defer server.Close()
+	client := klse.NewClient(klse.WithBaseURL(server.URL), klse.WithChartRateLimit(0, 0))
+	if _, err := client.GetStockHistoricalData("0000"); !errors.Is(err, klse.ErrParse) {
+		t.Errorf("truncated err = %v, want ErrParse", err)
+	}
+}
+
+// pageTransport is the transport which responses the page to every request.
+type pageTransport []byte
+
+// RoundTrip is to implement http.RoundTripper.
+func (t pageTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Header: http.Header{},
+		Body: io.NopCloser(bytes.NewReader(t)), Request: r}, nil
+}
+
+// FuzzReadChart is to check the chart reader never panics or hangs, the
+// bars returned with nil or *SkippedRowsError are parsed.
+func FuzzReadChart(f *testing.F) {
+	f.Add([]byte("<script>var data = [\n[1651449600000,0.250,0.275,0.245,0.270,700000],\n];</script>"))
+	f.Add([]byte("<script>var data = [[1651449600000,0.250,null,0.245,0.270,700000,0.26,],,];</script>"))
+	f.Add([]byte(`<script>/* data = [ */ var s = 'data = ['; var data = [[1, "2", [3, {"a": "]"}]], 4];</script>`))
+	f.Add([]byte("<script>var data = [[1,2"))
+	f.Add([]byte("<html>not found</html>"))
+	f.Add([]byte("<title>D'NONCE Chart</title><script>var data = [[1651449600000,1,1,1,1,100]];</script>"))
+	f.Fuzz(func(t *testing.T, page []byte) {
+		client := klse.NewClient(klse.WithHTTPClient(&http.Client{Transport: pageTransport(page)}),
+			klse.WithChartRateLimit(0, 0), klse.WithRetryPolicy(klse.RetryPolicy{}), klse.WithClock(fixtureClock))
+		bars, err := client.GetStockHistoricalData("0020I")
+		if err == nil || errors.Is(err, klse.ErrSkippedRows) {
+			for _, bar := range bars {
+				if bar == nil || bar.Date.IsZero() {
+					t.Fatalf("invalid bar %+v", bar)
+				}
+			}
+			return
+		}
+		if bars != nil {
+			t.Fatalf("bars = %d with error %v", len(bars), err)
+		}
+		if !errors.Is(err, klse.ErrParse) && !errors.Is(err, klse.ErrNoChartData) {
+			t.Fatalf("err = %v, want ErrParse or ErrNoChartData", err)
+		}
+	})
+}
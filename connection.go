@@ -1,11 +1,12 @@
 package klse
 
 import (
+	"bytes"
+	"context"
 	"io"
-	"log"
+	"io/ioutil"
 	"math"
 	"net/http"
-	"os"
 	"regexp"
 	"strconv"
 	"strings"
@@ -15,26 +16,167 @@ import (
 // klsescreenerBaseURL is klsecreener.com base URL.
 const klescreenerBaseURL = "https://www.klsescreener.com"
 
-var regexpSpaces = regexp.MustCompile(`\s+`) // regular expression to find all spaces
+// defaultUserAgent is the user agent sent with every request,
+// klsescreener won't response without a browser user agent.
+const defaultUserAgent = "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/103.0.5060.53 Safari/537.36"
 
 var (
-	logError   = log.New(os.Stdout, "[ERROR]", log.LstdFlags|log.Lshortfile)   // error log, will execute os.Exit(1)
-	logWarning = log.New(os.Stdout, "[WARNING]", log.LstdFlags|log.Lshortfile) // warning log
-	logInfo    = log.New(os.Stdout, "[INFO]", log.LstdFlags|log.Lshortfile)    // info log
+	regexpSpaces    = regexp.MustCompile(`\s+`)       // regular expression to find all spaces
+	regexpMagnitude = regexp.MustCompile(`[a-zA-Z]$`) // regular expression to find the magnitude eg "B"
 )
 
-// newRequest is http request from klsescreener website
-func newRequest(method, url string, body io.Reader, headers ...map[string]string) *http.Response {
-	client := http.Client{}
-	req, err := http.NewRequest(method, url, body)
+// Client is the klsescreener client, all the fetchers are available
+// as methods of the client.
+// example : client := NewClient(WithBaseURL("http://mirror.internal"))
+//
+//	market, err := client.GetMarketInformation()
+//	if err != nil {
+//		return err
+//	}
+type Client struct {
+	httpClient   *http.Client
+	baseURL      string
+	userAgent    string
+	timeout      time.Duration
+	retryPolicy  RetryPolicy
+	limiter      *rateLimiter
+	chartLimiter *rateLimiter
+	logger       Logger
+	cache        Cache
+	cacheTTL     map[Endpoint]time.Duration
+	exact        bool // parse the Exact decimals of the results.
+	clock        func() time.Time
+}
+
+// ClientOption is the option to configure the client.
+type ClientOption func(c *Client)
+
+// DefaultClient is the client used by the package level functions.
+var DefaultClient = NewClient()
+
+// NewClient is to initialise a client with options.
+// options = function start with "With".
+func NewClient(options ...ClientOption) *Client {
+	c := &Client{
+		httpClient:   &http.Client{},
+		baseURL:      klescreenerBaseURL,
+		userAgent:    defaultUserAgent,
+		retryPolicy:  DefaultRetryPolicy(),
+		limiter:      newRateLimiter(defaultRateLimit, defaultRateBurst),
+		chartLimiter: newRateLimiter(defaultChartRateLimit, defaultChartRateBurst),
+		logger:       nopLogger{},
+		clock:        time.Now,
+	}
+	for _, option := range options {
+		option(c)
+	}
+	// copy the http client so the timeout won't change the caller's client.
+	if c.timeout > 0 {
+		httpClient := *c.httpClient
+		httpClient.Timeout = c.timeout
+		c.httpClient = &httpClient
+	}
+	return c
+}
+
+// WithHTTPClient is the option to use own http client for requests.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+// WithBaseURL is the option to change the klsescreener base URL,
+// eg an internal mirror or a test server.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithUserAgent is the option to change the request user agent.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithTimeout is the option to set the time limit for every request.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// newRequest is http request from klsescreener website.
+// path is the url path after base URL, eg "/v2/markets".
+// Non 2xx response will return *StatusError and the body is closed.
+// The response is from the client's cache if available, otherwise
+// every attempt waits for the client's rate limit and failed request
+// is retried based on the client's retry policy.
+func (c *Client) newRequest(ctx context.Context, method, path string, body io.Reader, headers ...map[string]string) (*http.Response, error) {
+	// keep the body to send it again for every attempt.
+	var payload []byte
+	if body != nil {
+		b, err := ioutil.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+		payload = b
+	}
+	key := cacheKey(method, c.baseURL+path, payload)
+	if resp, ok := c.getCache(key, path); ok {
+		c.logger.Log(LogDebug, "cache hit", Field{Key: "method", Value: method}, Field{Key: "url", Value: c.baseURL + path})
+		return resp, nil
+	}
+	for attempt := 1; ; attempt++ {
+		if err := c.waitRateLimit(ctx, path); err != nil {
+			return nil, err
+		}
+		resp, err := c.doRequest(ctx, method, path, payload, headers...)
+		if err == nil {
+			if err := c.setCache(key, path, resp); err != nil {
+				resp.Body.Close()
+				return nil, err
+			}
+			return resp, nil
+		}
+		if attempt >= c.retryPolicy.MaxAttempts ||
+			!c.retryPolicy.allowMethod(method) ||
+			!shouldRetry(ctx, err) {
+			return nil, err
+		}
+		delay, ok := c.retryPolicy.delay(attempt, err)
+		if !ok {
+			return nil, err
+		}
+		c.logger.Log(LogWarning, "retrying request",
+			Field{Key: "url", Value: c.baseURL + path},
+			Field{Key: "attempt", Value: attempt},
+			Field{Key: "delay", Value: delay},
+			Field{Key: "error", Value: err},
+		)
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// doRequest is a single attempt of newRequest.
+func (c *Client) doRequest(ctx context.Context, method, path string, payload []byte, headers ...map[string]string) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
 	if err != nil {
-		logError.Fatalf("%s : %s", req.URL, err)
+		return nil, err
 	}
 
 	// won't work if without this header setting
-	req.Header = http.Header{
-		"user-agent": []string{"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/103.0.5060.53 Safari/537.36"},
-	}
+	req.Header.Set("User-Agent", c.userAgent)
 
 	// loop the headers from arguements to set to request headers.
 	for _, header := range headers {
@@ -44,55 +186,95 @@ func newRequest(method, url string, body io.Reader, headers ...map[string]string
 	}
 
 	// get response from request
-	resp, err := client.Do(req)
+	c.logger.Log(LogDebug, "request", Field{Key: "method", Value: method}, Field{Key: "url", Value: req.URL.String()})
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		logError.Fatalf("%s, %s : %s", req.URL, resp.Status, err.Error())
+		return nil, err
 	}
-	return resp
-}
-
-// convertStringToDate is to convert string to type time.Time.
-func convertStringToDate(date, format string) time.Time {
-	resultDate, err := time.Parse(format, date)
-	if err != nil {
-		logWarning.Printf("%s : %s\n", date, err.Error())
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		resp.Body.Close()
+		return nil, &StatusError{
+			URL:        req.URL.String(),
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		}
 	}
-	return resultDate
+	return resp, nil
 }
 
 // convertMagnitudeToFloat64 is to convert "K", M", "B" to float64 type.
 func convertMagnitudeToFloat64(numberString string, decimal int) float64 {
-	var number float64
-	regexpMagnitude := regexp.MustCompile(`[a-zA-Z]$`)
+	number, _ := parseMagnitude(numberString, decimal)
+	return number
+}
+
+// parseMagnitude is to convert number with magnitude "K", "M", "B" to float64,
+// the number without magnitude is converted as it is. The number is scaled
+// before rounding to decimal, eg "1.62B" is 1620000000 and "850" is 850.
+func parseMagnitude(numberString string, decimal int) (float64, error) {
+	var power float64 = 1
 	magnitude := regexpMagnitude.FindString(numberString)
-	numberString = regexpMagnitude.ReplaceAllString(numberString, "")
 	switch strings.ToLower(magnitude) {
 	case "k": // thousands
-		number = convertStringToFloat64(numberString, 0) * 1000
+		power = 1e3
 	case "m": // millions
-		number = convertStringToFloat64(numberString, 0) * 1000000
+		power = 1e6
 	case "b": // billions
-		number = convertStringToFloat64(numberString, 0) * 1000000000
+		power = 1e9
 	}
-	decimalPower := float64(math.Pow(10, float64(decimal)))
-	number = math.Round(number*decimalPower) / decimalPower
-	return number
+	if power != 1 {
+		numberString = regexpMagnitude.ReplaceAllString(numberString, "")
+	}
+	number, err := parseFloat64(numberString, -1)
+	if err != nil {
+		return 0, err
+	}
+	return roundFloat64(number*power, decimal), nil
 }
 
 // convertStringToFloat64 is to convert any number in string to float64.
 // decimal is after convert how many places of decimal needed.
 func convertStringToFloat64(numberString string, decimal int) float64 {
+	number, _ := parseFloat64(numberString, decimal)
+	return number
+}
+
+// parseFloat64 is convertStringToFloat64 with the error of conversion,
+// the error is ErrNoValue for empty string and "-". decimal -1 is not rounded.
+func parseFloat64(numberString string, decimal int) (float64, error) {
 	replacement := []string{",", "%"}
 	for _, v := range replacement {
 		numberString = strings.ReplaceAll(numberString, v, "")
 	}
+	numberString = strings.TrimSpace(numberString)
+	if isNoValue(numberString) {
+		return 0, ErrNoValue
+	}
 	number, err := strconv.ParseFloat(numberString, 64)
 	if err != nil {
-		return 0
+		return 0, err
+	}
+	return roundFloat64(number, decimal), nil
+}
+
+// roundFloat64 is to round the number to the places of decimal,
+// decimal -1 is not rounded.
+func roundFloat64(number float64, decimal int) float64 {
+	if decimal < 0 {
+		return number
 	}
 	decimalPower := float64(math.Pow(10, float64(decimal)))
-	number = math.Round(number*decimalPower) / decimalPower
-	return number
+	return math.Round(number*decimalPower) / decimalPower
+}
+
+// isNoValue is to check the text is the placeholder of no value, eg "-".
+func isNoValue(text string) bool {
+	switch strings.ToLower(text) {
+	case "", "-", "--", "n/a":
+		return true
+	}
+	return false
 }
 
 // removeAllSpaces is to replace all spaces, tabs, new line.
@@ -0,0 +1,124 @@
+package klse_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	klse "github.com/kokweikhong/klsescreener-scraper"
+)
+
+func TestClientWithBaseURL(t *testing.T) {
+	var path, userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		userAgent = r.Header.Get("User-Agent")
+		w.Write([]byte(`<html><body><div id="content"></div></body></html>`))
+	}))
+	defer server.Close()
+
+	client := klse.NewClient(
+		klse.WithBaseURL(server.URL+"/"),
+		klse.WithUserAgent("klse-test"),
+		klse.WithTimeout(5*time.Second),
+	)
+	if _, err := client.GetMarketInformation(); err != nil {
+		t.Fatal(err)
+	}
+	if path != "/v2/markets" {
+		t.Errorf("path = %q, want %q", path, "/v2/markets")
+	}
+	if userAgent != "klse-test" {
+		t.Errorf("user agent = %q, want %q", userAgent, "klse-test")
+	}
+}
+
+func TestClientWithHTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><body></body></html>`))
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{}
+	client := klse.NewClient(
+		klse.WithHTTPClient(httpClient),
+		klse.WithBaseURL(server.URL),
+		klse.WithTimeout(time.Second),
+	)
+	if _, err := client.GetCompanyOverview("0001"); err != nil {
+		t.Fatal(err)
+	}
+	if httpClient.Timeout != 0 {
+		t.Errorf("caller's http client timeout changed to %v", httpClient.Timeout)
+	}
+}
+
+func TestClientStatusError(t *testing.T) {
+	tests := []struct {
+		status int
+		target error
+	}{
+		{http.StatusNotFound, klse.ErrNotFound},
+		{http.StatusTooManyRequests, klse.ErrRateLimited},
+		{http.StatusBadGateway, klse.ErrUpstreamStatus},
+	}
+	for _, test := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(test.status)
+		}))
+		client := klse.NewClient(
+			klse.WithBaseURL(server.URL),
+			klse.WithRetryPolicy(klse.RetryPolicy{}),
+		)
+		_, err := client.GetCompanyOverview("0000")
+		server.Close()
+		if !errors.Is(err, test.target) || !errors.Is(err, klse.ErrUpstreamStatus) {
+			t.Errorf("status %d: err = %v, want %v", test.status, err, test.target)
+		}
+		var statusErr *klse.StatusError
+		if !errors.As(err, &statusErr) || statusErr.StatusCode != test.status {
+			t.Errorf("status %d: err = %v, want *StatusError", test.status, err)
+		}
+	}
+}
+
+func TestClientNetworkError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	client := klse.NewClient(
+		klse.WithBaseURL(server.URL),
+		klse.WithRetryPolicy(klse.RetryPolicy{}),
+	)
+	if _, err := client.NewAnnouncementRequest().GetRecentDividendEntitlements(); err == nil {
+		t.Error("want error from closed server")
+	}
+}
+
+func TestClientContextCancel(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	client := klse.NewClient(klse.WithBaseURL(server.URL))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetStockHistoricalDataContext(ctx, "0001"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	quote := client.NewQuoteResultRequest()
+	if _, err := quote.GetQuoteResultsContext(cancelled, quote.WithMinPE(1)); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want %v", err, context.Canceled)
+	}
+}
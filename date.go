@@ -0,0 +1,179 @@
+package klse
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// MYT is the time zone of Bursa Malaysia, Asia/Kuala_Lumpur (UTC+8).
+// All the dates are parsed in MYT, eg ex date "02 Jan 2006" is midnight MYT.
+var MYT = loadMYT()
+
+// loadMYT is to load Asia/Kuala_Lumpur from the time zone database,
+// the fixed UTC+8 is used when the database is not available.
+func loadMYT() *time.Location {
+	location, err := time.LoadLocation("Asia/Kuala_Lumpur")
+	if err != nil {
+		return time.FixedZone("MYT", 8*60*60)
+	}
+	return location
+}
+
+// TradingDate is the calendar date of a Bursa trading day in MYT,
+// it is comparable and can be the key of map.
+type TradingDate struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// NewTradingDate is to get the date of t in MYT, eg 2022-07-07 16:00 UTC
+// is trading date 2022-07-08.
+func NewTradingDate(t time.Time) TradingDate {
+	year, month, day := t.In(MYT).Date()
+	return TradingDate{Year: year, Month: month, Day: day}
+}
+
+// ParseTradingDate is to parse the trading date in "2006-01-02".
+func ParseTradingDate(s string) (TradingDate, error) {
+	t, err := time.ParseInLocation("2006-01-02", s, MYT)
+	if err != nil {
+		return TradingDate{}, err
+	}
+	return NewTradingDate(t), nil
+}
+
+// Time is the midnight MYT of the trading date.
+func (d TradingDate) Time() time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, MYT)
+}
+
+// AddDays is the date n calendar days later, n can be negative.
+func (d TradingDate) AddDays(n int) TradingDate {
+	return NewTradingDate(time.Date(d.Year, d.Month, d.Day+n, 12, 0, 0, 0, MYT))
+}
+
+// Before is to check d is before x.
+func (d TradingDate) Before(x TradingDate) bool {
+	return d.Time().Before(x.Time())
+}
+
+// After is to check d is after x.
+func (d TradingDate) After(x TradingDate) bool {
+	return d.Time().After(x.Time())
+}
+
+// IsZero is to check d is the zero date.
+func (d TradingDate) IsZero() bool {
+	return d == TradingDate{}
+}
+
+// String is the date in "2006-01-02".
+func (d TradingDate) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// MarshalJSON is to render the date as "2006-01-02".
+func (d TradingDate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON is to read the date from "2006-01-02".
+func (d *TradingDate) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	date, err := ParseTradingDate(s)
+	if err != nil {
+		return err
+	}
+	*d = date
+	return nil
+}
+
+// WithClock is the option to set the clock of the client, the time of the
+// scraping is used to infer the year of the dates without year, eg "02 Jan".
+// It is time.Now by default.
+func WithClock(now func() time.Time) ClientOption {
+	return func(c *Client) {
+		if now == nil {
+			now = time.Now
+		}
+		c.clock = now
+	}
+}
+
+// day is the length of a calendar day.
+const day = 24 * time.Hour
+
+// yearWindow is the range around the time of the scraping where the dates
+// without year of a table are expected.
+type yearWindow struct {
+	before time.Duration
+	after  time.Duration
+}
+
+var (
+	// nearestWindow is for the table of both recent and upcoming dates.
+	nearestWindow = yearWindow{before: 183 * day, after: 183 * day}
+	// recentWindow is for the table of recent dates, eg announced date.
+	recentWindow = yearWindow{before: 330 * day, after: 31 * day}
+	// upcomingWindow is for the table of upcoming dates, eg upcoming ex date.
+	upcomingWindow = yearWindow{before: 31 * day, after: 330 * day}
+)
+
+// resolve is to infer the year of the month and day, the date is the one
+// nearest to now within the window. ok is false when there is no date
+// within the window and the nearest one is returned.
+func (w yearWindow) resolve(month time.Month, dayOfMonth int, now time.Time) (date time.Time, ok bool) {
+	today := NewTradingDate(now).Time()
+	var nearest time.Time
+	for year := today.Year() - 1; year <= today.Year()+1; year++ {
+		candidate := time.Date(year, month, dayOfMonth, 0, 0, 0, 0, MYT)
+		if candidate.Day() != dayOfMonth {
+			continue // 29 Feb of the year is not leap year.
+		}
+		distance := absDuration(candidate.Sub(today))
+		within := candidate.Sub(today) >= -w.before && candidate.Sub(today) <= w.after
+		if within && (!ok || distance < absDuration(date.Sub(today))) {
+			date, ok = candidate, true
+		}
+		if nearest.IsZero() || distance < absDuration(nearest.Sub(today)) {
+			nearest = candidate
+		}
+	}
+	if !ok {
+		return nearest, false
+	}
+	return date, true
+}
+
+// absDuration is the absolute value of d.
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// dateWithoutYear is to convert the text of the field without year, eg
+// "02 Jan", to time.Time with the year inferred from the time of scraping.
+func (p *parser) dateWithoutYear(field, text, layout string, window yearWindow) time.Time {
+	if isNoValue(text) {
+		p.issue(field, text, ErrNoValue)
+		return time.Time{}
+	}
+	parsed, err := time.ParseInLocation(layout, text, MYT)
+	if err != nil {
+		p.issue(field, text, err)
+		return time.Time{}
+	}
+	date, ok := window.resolve(parsed.Month(), parsed.Day(), p.now)
+	if !ok {
+		p.issue(field, text, fmt.Errorf("no year of the date within %v before and %v after %s",
+			window.before, window.after, p.now.Format("2006-01-02")))
+	}
+	return date
+}
@@ -0,0 +1,179 @@
+package klse_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	klse "github.com/kokweikhong/klsescreener-scraper"
+)
+
+// fixtureClock is the time when the fixtures were recorded.
+func fixtureClock() time.Time {
+	return time.Date(2022, time.July, 8, 18, 0, 0, 0, time.UTC)
+}
+
+// fixedClock is the clock of the fixed time.
+func fixedClock(year int, month time.Month, day int) func() time.Time {
+	return func() time.Time {
+		return time.Date(year, month, day, 10, 0, 0, 0, klse.MYT)
+	}
+}
+
+// entitlementServer is the test server serves the entitlements page with the tables.
+func entitlementServer(tables ...string) *testServer {
+	return pageServer(strings.Join(tables, ""))
+}
+
+// shareIssueTable is the share issues table with the ex date.
+func shareIssueTable(exDate string) string {
+	return `<table><thead><tr><th>EX Date</th><th>Stock</th><th>Subject</th><th>Ratio</th><th>Offer Price</th>` +
+		`<th>Type</th><th></th></tr></thead><tbody><tr><td>` + exDate + `</td><td><a href="/v2/stocks/view/0001">A</a></td>` +
+		`<td>Bonus Issue</td><td>1 : 1</td><td>-</td><td>Bonus</td><td></td></tr></tbody></table>`
+}
+
+func TestDividendEntitlementsYear(t *testing.T) {
+	server := entitlementServer(`<table><thead><tr><th>EX Date</th><th>Stock</th><th>Subject</th><th>Amount</th>` +
+		`<th>Type</th><th></th></tr></thead><tbody>` +
+		`<tr><td>03 Jan</td><td><a href="/v2/stocks/view/0001">A</a></td><td>Dividend</td><td>0.01</td><td>Currency</td><td></td></tr>` +
+		`<tr><td>20 Dec</td><td><a href="/v2/stocks/view/0002">B</a></td><td>Dividend</td><td>0.02</td><td>Currency</td><td></td></tr>` +
+		`</tbody></table>`)
+	defer server.Close()
+
+	tests := []struct {
+		clock func() time.Time
+		want  []time.Time
+	}{
+		{fixedClock(2022, 12, 28), []time.Time{time.Date(2023, 1, 3, 0, 0, 0, 0, klse.MYT), time.Date(2022, 12, 20, 0, 0, 0, 0, klse.MYT)}},
+		{fixedClock(2023, 1, 2), []time.Time{time.Date(2023, 1, 3, 0, 0, 0, 0, klse.MYT), time.Date(2022, 12, 20, 0, 0, 0, 0, klse.MYT)}},
+		{fixedClock(2023, 6, 15), []time.Time{time.Date(2023, 1, 3, 0, 0, 0, 0, klse.MYT), time.Date(2022, 12, 20, 0, 0, 0, 0, klse.MYT)}},
+	}
+	for _, tt := range tests {
+		client := klse.NewClient(klse.WithBaseURL(server.URL), klse.WithClock(tt.clock))
+		data, err := client.NewAnnouncementRequest().GetRecentDividendEntitlements()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, want := range tt.want {
+			if !data[i].ExpireDate.Equal(want) {
+				t.Errorf("scraped at %v, ex date = %v, want %v", tt.clock(), data[i].ExpireDate, want)
+			}
+		}
+	}
+}
+
+func TestShareIssuedEntitlementsYear(t *testing.T) {
+	tests := []struct {
+		clock    func() time.Time
+		recent   string
+		upcoming string
+		want     [2]time.Time
+	}{
+		// upcoming ex date in January is next year in December.
+		{fixedClock(2022, 12, 28), "20 Dec", "05 Jan",
+			[2]time.Time{time.Date(2022, 12, 20, 0, 0, 0, 0, klse.MYT), time.Date(2023, 1, 5, 0, 0, 0, 0, klse.MYT)}},
+		// recent ex date in December is last year in January.
+		{fixedClock(2023, 1, 2), "28 Dec", "10 Feb",
+			[2]time.Time{time.Date(2022, 12, 28, 0, 0, 0, 0, klse.MYT), time.Date(2023, 2, 10, 0, 0, 0, 0, klse.MYT)}},
+		// recent is up to a year ago and upcoming is up to a year later.
+		{fixedClock(2022, 7, 1), "15 Aug", "01 May",
+			[2]time.Time{time.Date(2021, 8, 15, 0, 0, 0, 0, klse.MYT), time.Date(2023, 5, 1, 0, 0, 0, 0, klse.MYT)}},
+		// 29 Feb is resolved to the leap year.
+		{fixedClock(2023, 12, 20), "05 Dec", "29 Feb",
+			[2]time.Time{time.Date(2023, 12, 5, 0, 0, 0, 0, klse.MYT), time.Date(2024, 2, 29, 0, 0, 0, 0, klse.MYT)}},
+	}
+	for _, tt := range tests {
+		server := entitlementServer(shareIssueTable(tt.recent), shareIssueTable(tt.upcoming))
+		client := klse.NewClient(klse.WithBaseURL(server.URL), klse.WithClock(tt.clock))
+		data, err := client.NewAnnouncementRequest().GetShareIssuedEntitlements()
+		server.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		recent, upcoming := data.RecentShareIssues[0], data.UpcomingShareIssues[0]
+		if !recent.ExpireDate.Equal(tt.want[0]) || !upcoming.ExpireDate.Equal(tt.want[1]) {
+			t.Errorf("scraped at %v, recent %s = %v, upcoming %s = %v, want %v",
+				tt.clock(), tt.recent, recent.ExpireDate, tt.upcoming, upcoming.ExpireDate, tt.want)
+		}
+		if !recent.Report.Valid("expired_date") || !upcoming.Report.Valid("expired_date") {
+			t.Errorf("parse report = %v, %v", recent.Report, upcoming.Report)
+		}
+	}
+}
+
+func TestQuarterReportAnnouncementYear(t *testing.T) {
+	server := entitlementServer(`<table><thead><tr><th>Announced</th><th>Stock</th><th>Quarter</th><th>Q Date</th>` +
+		`<th>Revenue</th><th>Revenue %</th><th>Net Profit</th><th>QoQ</th><th>YoY</th><th>EPS</th><th>Dividend</th><th></th>` +
+		`</tr></thead><tbody><tr><td>30 Dec</td><td><a href="/v2/stocks/view/0001">A</a></td><td>1</td><td>2022-09-30</td>` +
+		`<td>1</td><td></td><td>1</td><td></td><td></td><td>1</td><td>0</td><td></td></tr></tbody></table>`)
+	defer server.Close()
+
+	client := klse.NewClient(klse.WithBaseURL(server.URL), klse.WithClock(fixedClock(2023, 1, 2)))
+	data, err := client.NewAnnouncementRequest().GetQuarterReportAnnouncement()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2022, 12, 30, 0, 0, 0, 0, klse.MYT); !data[0].AnnouncedDate.Equal(want) {
+		t.Errorf("announced date = %v, want %v", data[0].AnnouncedDate, want)
+	}
+}
+
+func TestTradingDate(t *testing.T) {
+	tests := []struct {
+		time time.Time
+		want klse.TradingDate
+	}{
+		{time.Date(2022, 7, 7, 16, 0, 0, 0, time.UTC), klse.TradingDate{Year: 2022, Month: time.July, Day: 8}},
+		{time.Date(2022, 7, 7, 15, 59, 0, 0, time.UTC), klse.TradingDate{Year: 2022, Month: time.July, Day: 7}},
+		{time.Date(2022, 7, 8, 0, 0, 0, 0, klse.MYT), klse.TradingDate{Year: 2022, Month: time.July, Day: 8}},
+	}
+	for _, tt := range tests {
+		if got := klse.NewTradingDate(tt.time); got != tt.want {
+			t.Errorf("NewTradingDate(%v) = %v, want %v", tt.time, got, tt.want)
+		}
+	}
+
+	date := klse.TradingDate{Year: 2022, Month: time.February, Day: 28}
+	if next := date.AddDays(1); next.String() != "2022-03-01" || !next.After(date) || next.AddDays(-1) != date {
+		t.Errorf("AddDays(1) = %v", next)
+	}
+	b, err := json.Marshal(date)
+	if err != nil || string(b) != `"2022-02-28"` {
+		t.Fatalf("json = %s, %v", b, err)
+	}
+	var parsed klse.TradingDate
+	if err := json.Unmarshal(b, &parsed); err != nil || parsed != date {
+		t.Errorf("unmarshal = %v, %v", parsed, err)
+	}
+}
+
+func TestChartTradingDate(t *testing.T) {
+	// the chart timestamps of midnight MYT and midnight UTC are the same trading date.
+	server := chartPageServer(`[1657209600000,1,2,0.5,1.5,100],[1657238400000,1,2,0.5,1.5,100],`)
+	defer server.Close()
+
+	client := klse.NewClient(klse.WithBaseURL(server.URL), klse.WithChartRateLimit(0, 0))
+	data, err := client.GetStockHistoricalData("0001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := klse.TradingDate{Year: 2022, Month: time.July, Day: 8}
+	for _, ohlc := range data {
+		if ohlc.TradingDate() != want || !ohlc.Date.Equal(want.Time()) {
+			t.Errorf("date = %v, trading date = %v, want %v", ohlc.Date, ohlc.TradingDate(), want)
+		}
+	}
+}
+
+func TestCompanyStatisticTradingDate(t *testing.T) {
+	// 2022-07-08 17:00 UTC is 2022-07-09 01:00 MYT.
+	clock := func() time.Time { return time.Date(2022, 7, 8, 17, 0, 0, 0, time.UTC) }
+	company, err := newFixtureClient(t, klse.WithClock(clock)).GetCompanyOverview("6947")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := company.Statistic.OHLC.TradingDate(); got != (klse.TradingDate{Year: 2022, Month: time.July, Day: 9}) {
+		t.Errorf("trading date = %v, want 2022-07-09", got)
+	}
+}
@@ -0,0 +1,340 @@
+package klse
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// WithExactDecimals is the option to parse the prices and financial
+// figures as Decimal in the Exact field of OHLC, QuoteResult,
+// DividendsReport, DividentEntitlements and share issues.
+func WithExactDecimals() ClientOption {
+	return func(c *Client) {
+		c.exact = true
+	}
+}
+
+// decimal is to convert the text of the field to Decimal when the exact
+// decimals are enabled, the parse issue is reported unless the float of the
+// same field has reported it. It is zero without WithExactDecimals.
+func (p *parser) decimal(field, text string) Decimal {
+	if !p.exact {
+		return Decimal{}
+	}
+	for _, v := range []string{",", "%"} {
+		text = strings.ReplaceAll(text, v, "")
+	}
+	text = strings.TrimSpace(text)
+	if isNoValue(text) {
+		return Decimal{}
+	}
+	d, err := parseDecimal(text, true)
+	if err != nil {
+		if p.report.Valid(field) {
+			p.issue(field, text, err)
+		}
+		return Decimal{}
+	}
+	return d
+}
+
+// DecimalPlaces is the fixed places of decimal of Decimal.
+const DecimalPlaces = 8
+
+// decimalScale is 10^DecimalPlaces, the units of 1.
+const decimalScale = 100000000
+
+// errDecimalRange is returned when the number is out of the range of Decimal.
+var errDecimalRange = errors.New("klse: decimal out of range")
+
+// Decimal is the fixed-point number with 8 places of decimal for prices and
+// financial figures, eg dividend 0.0125 x 1000 shares is exactly 12.5.
+// The zero value is null (Valid false), the arithmetic with null is null.
+// The range is about ±92 billion.
+type Decimal struct {
+	units int64 // value x 10^DecimalPlaces.
+	Valid bool
+}
+
+// NewDecimal is to initialise Decimal of value x 10^exp, eg NewDecimal(125, -4) is 0.0125.
+func NewDecimal(value int64, exp int) Decimal {
+	n := new(big.Int).SetInt64(value)
+	return decimalFromBig(scaleBig(n, exp+DecimalPlaces))
+}
+
+// DecimalFromInt is to initialise Decimal of the integer.
+func DecimalFromInt(value int64) Decimal {
+	return NewDecimal(value, 0)
+}
+
+// DecimalFromFloat is to initialise Decimal of the shortest representation
+// of the float, rounded to 8 places of decimal. NaN and Inf are null.
+func DecimalFromFloat(value float64) Decimal {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return Decimal{}
+	}
+	d, err := parseDecimal(strconv.FormatFloat(value, 'f', -1, 64), true)
+	if err != nil {
+		return Decimal{}
+	}
+	return d
+}
+
+// ParseDecimal is to convert the number in string to Decimal, eg "-1234.5678".
+// The number with more than 8 places of decimal is an error.
+func ParseDecimal(s string) (Decimal, error) {
+	return parseDecimal(s, false)
+}
+
+// MustParseDecimal is ParseDecimal which panics on error, for constants.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// parseDecimal is to parse the number, the places of decimal more than 8
+// are rounded when round is true, otherwise it is an error.
+func parseDecimal(s string, round bool) (Decimal, error) {
+	text := s
+	negative := false
+	if strings.HasPrefix(text, "-") || strings.HasPrefix(text, "+") {
+		negative = text[0] == '-'
+		text = text[1:]
+	}
+	integer, fraction := text, ""
+	if i := strings.IndexByte(text, '.'); i >= 0 {
+		integer, fraction = text[:i], text[i+1:]
+	}
+	if integer == "" && fraction == "" || !isDigits(integer) || !isDigits(fraction) {
+		return Decimal{}, fmt.Errorf("klse: invalid decimal %q", s)
+	}
+	if len(fraction) > DecimalPlaces && !round {
+		return Decimal{}, fmt.Errorf("klse: decimal %q has more than %d places", s, DecimalPlaces)
+	}
+	n, _ := new(big.Int).SetString("0"+integer+fraction, 10)
+	if negative {
+		n.Neg(n)
+	}
+	d := decimalFromBig(scaleBig(n, DecimalPlaces-len(fraction)))
+	if !d.Valid {
+		return Decimal{}, fmt.Errorf("%w: %q", errDecimalRange, s)
+	}
+	return d, nil
+}
+
+// isDigits is to check the string contains only 0-9.
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// scaleBig is to multiply n by 10^exp, the negative exp is divided and
+// rounded half away from zero.
+func scaleBig(n *big.Int, exp int) *big.Int {
+	if exp >= 0 {
+		return n.Mul(n, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil))
+	}
+	return divRound(n, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-exp)), nil))
+}
+
+// divRound is n / d rounded half away from zero.
+func divRound(n, d *big.Int) *big.Int {
+	q, r := new(big.Int).QuoRem(n, d, new(big.Int))
+	if r.Sign() != 0 && new(big.Int).Mul(new(big.Int).Abs(r), big.NewInt(2)).Cmp(new(big.Int).Abs(d)) >= 0 {
+		if (n.Sign() < 0) != (d.Sign() < 0) {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+// decimalFromBig is to initialise Decimal of the units, null when out of range.
+func decimalFromBig(units *big.Int) Decimal {
+	if !units.IsInt64() {
+		return Decimal{}
+	}
+	return Decimal{units: units.Int64(), Valid: true}
+}
+
+// Add is d + x.
+func (d Decimal) Add(x Decimal) Decimal {
+	if !d.Valid || !x.Valid {
+		return Decimal{}
+	}
+	return decimalFromBig(new(big.Int).Add(big.NewInt(d.units), big.NewInt(x.units)))
+}
+
+// Sub is d - x.
+func (d Decimal) Sub(x Decimal) Decimal {
+	if !d.Valid || !x.Valid {
+		return Decimal{}
+	}
+	return decimalFromBig(new(big.Int).Sub(big.NewInt(d.units), big.NewInt(x.units)))
+}
+
+// Mul is d x x rounded half away from zero to 8 places of decimal.
+func (d Decimal) Mul(x Decimal) Decimal {
+	if !d.Valid || !x.Valid {
+		return Decimal{}
+	}
+	n := new(big.Int).Mul(big.NewInt(d.units), big.NewInt(x.units))
+	return decimalFromBig(divRound(n, big.NewInt(decimalScale)))
+}
+
+// Div is d / x rounded half away from zero to 8 places of decimal,
+// it is null when x is zero.
+func (d Decimal) Div(x Decimal) Decimal {
+	if !d.Valid || !x.Valid || x.units == 0 {
+		return Decimal{}
+	}
+	n := new(big.Int).Mul(big.NewInt(d.units), big.NewInt(decimalScale))
+	return decimalFromBig(divRound(n, big.NewInt(x.units)))
+}
+
+// Neg is -d.
+func (d Decimal) Neg() Decimal {
+	if !d.Valid {
+		return Decimal{}
+	}
+	return decimalFromBig(new(big.Int).Neg(big.NewInt(d.units)))
+}
+
+// Round is to round d half away from zero to the places of decimal.
+func (d Decimal) Round(places int) Decimal {
+	if !d.Valid || places >= DecimalPlaces {
+		return d
+	}
+	return decimalFromBig(scaleBig(scaleBig(big.NewInt(d.units), places-DecimalPlaces), DecimalPlaces-places))
+}
+
+// Cmp is to compare d and x, -1 if d < x, 0 if d == x and +1 if d > x.
+// The null is less than any number.
+func (d Decimal) Cmp(x Decimal) int {
+	switch {
+	case !d.Valid || !x.Valid:
+		return boolCmp(d.Valid, x.Valid)
+	case d.units < x.units:
+		return -1
+	case d.units > x.units:
+		return 1
+	}
+	return 0
+}
+
+// boolCmp is to compare the valid flags, false is less than true.
+func boolCmp(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case b:
+		return -1
+	}
+	return 1
+}
+
+// Sign is -1 if d < 0, 0 if d is zero or null and +1 if d > 0.
+func (d Decimal) Sign() int {
+	switch {
+	case d.units < 0:
+		return -1
+	case d.units > 0:
+		return 1
+	}
+	return 0
+}
+
+// IsZero is to check d is valid zero.
+func (d Decimal) IsZero() bool {
+	return d.Valid && d.units == 0
+}
+
+// Float64 is to convert d to the nearest float64, null is 0.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String is the number without trailing zeros, eg "0.0125", null is "null".
+func (d Decimal) String() string {
+	if !d.Valid {
+		return "null"
+	}
+	units := d.units
+	sign := ""
+	if units < 0 {
+		sign = "-"
+	}
+	abs := new(big.Int).Abs(big.NewInt(units)).String()
+	if len(abs) <= DecimalPlaces {
+		abs = strings.Repeat("0", DecimalPlaces-len(abs)+1) + abs
+	}
+	integer, fraction := abs[:len(abs)-DecimalPlaces], strings.TrimRight(abs[len(abs)-DecimalPlaces:], "0")
+	if fraction == "" {
+		return sign + integer
+	}
+	return sign + integer + "." + fraction
+}
+
+// MarshalJSON is to render d as JSON number, null is null.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON is to read d from JSON number, string or null.
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if string(b) == "null" {
+		*d = Decimal{}
+		return nil
+	}
+	value, err := ParseDecimal(strings.Trim(string(b), `"`))
+	if err != nil {
+		return err
+	}
+	*d = value
+	return nil
+}
+
+// Value is to implement driver.Valuer, d is stored as string for
+// DECIMAL or NUMERIC column and null as NULL.
+func (d Decimal) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	return d.String(), nil
+}
+
+// Scan is to implement sql.Scanner for string, []byte, int64, float64 and NULL.
+func (d *Decimal) Scan(src interface{}) error {
+	var err error
+	switch v := src.(type) {
+	case nil:
+		*d = Decimal{}
+	case string:
+		*d, err = ParseDecimal(v)
+	case []byte:
+		*d, err = ParseDecimal(string(v))
+	case int64:
+		*d = DecimalFromInt(v)
+	case float64:
+		*d = DecimalFromFloat(v)
+	default:
+		err = fmt.Errorf("klse: can't scan %T into Decimal", src)
+	}
+	return err
+}
@@ -0,0 +1,183 @@
+package klse_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	klse "github.com/kokweikhong/klsescreener-scraper"
+	"github.com/kokweikhong/klsescreener-scraper/keys"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		err   bool
+	}{
+		{"0.0125", "0.0125", false},
+		{"-1234.50", "-1234.5", false},
+		{"+3", "3", false},
+		{".5", "0.5", false},
+		{"0.000000001", "", true},
+		{"1e5", "", true},
+		{"-", "", true},
+		{"99999999999", "", true},
+	}
+	for _, tt := range tests {
+		d, err := klse.ParseDecimal(tt.input)
+		if (err != nil) != tt.err {
+			t.Errorf("ParseDecimal(%q) error = %v", tt.input, err)
+			continue
+		}
+		if err == nil && d.String() != tt.want {
+			t.Errorf("ParseDecimal(%q) = %s, want %s", tt.input, d, tt.want)
+		}
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	dividend := klse.MustParseDecimal("0.0125")
+	shares := klse.DecimalFromInt(3 * 100)
+
+	// float64 0.0125 * 300 * 3 is not exactly 11.25.
+	total := dividend.Mul(shares).Add(dividend.Mul(shares)).Add(dividend.Mul(shares))
+	if total.String() != "11.25" || total.Cmp(klse.NewDecimal(1125, -2)) != 0 {
+		t.Errorf("total = %s, want 11.25", total)
+	}
+	if got := klse.DecimalFromInt(10).Div(klse.DecimalFromInt(3)); got.String() != "3.33333333" {
+		t.Errorf("10 / 3 = %s", got)
+	}
+	if got := klse.DecimalFromInt(2).Div(klse.DecimalFromInt(3)).Round(2); got.String() != "0.67" {
+		t.Errorf("round 2 / 3 = %s", got)
+	}
+	if got := klse.MustParseDecimal("-0.125").Round(2); got.String() != "-0.13" {
+		t.Errorf("round -0.125 = %s", got)
+	}
+	if got := klse.DecimalFromInt(1).Div(klse.Decimal{}); got.Valid {
+		t.Errorf("1 / null = %s, want null", got)
+	}
+	if got := klse.DecimalFromInt(1).Div(klse.DecimalFromInt(0)); got.Valid {
+		t.Errorf("1 / 0 = %s, want null", got)
+	}
+	if got := klse.DecimalFromFloat(0.1).Add(klse.DecimalFromFloat(0.2)); got.String() != "0.3" || got.Float64() != 0.3 {
+		t.Errorf("0.1 + 0.2 = %s", got)
+	}
+	if got := klse.MustParseDecimal("3.65").Sub(klse.MustParseDecimal("3.7")); got.Sign() != -1 || got.Neg().String() != "0.05" {
+		t.Errorf("3.65 - 3.7 = %s", got)
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	var v struct {
+		Price  klse.Decimal `json:"price"`
+		Amount klse.Decimal `json:"amount"`
+		Null   klse.Decimal `json:"null"`
+	}
+	if err := json.Unmarshal([]byte(`{"price": 3.650, "amount": "0.0125", "null": null}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"price":3.65,"amount":0.0125,"null":null}` {
+		t.Errorf("json = %s", b)
+	}
+}
+
+func TestDecimalSQL(t *testing.T) {
+	for _, src := range []interface{}{"0.0125", []byte("0.0125"), 0.0125} {
+		var d klse.Decimal
+		if err := d.Scan(src); err != nil || d.String() != "0.0125" {
+			t.Errorf("Scan(%v) = %s, %v", src, d, err)
+		}
+		if value, err := d.Value(); err != nil || value != "0.0125" {
+			t.Errorf("Value() = %v, %v", value, err)
+		}
+	}
+	var d klse.Decimal
+	if err := d.Scan(nil); err != nil || d.Valid {
+		t.Errorf("Scan(nil) = %s, %v", d, err)
+	}
+	if value, err := d.Value(); err != nil || value != nil {
+		t.Errorf("null Value() = %v, %v", value, err)
+	}
+	if err := d.Scan(true); err == nil {
+		t.Error("Scan(bool) error = nil")
+	}
+}
+
+func TestWithExactDecimals(t *testing.T) {
+	announcement := newFixtureClient(t).NewAnnouncementRequest()
+	entitlements, err := announcement.GetRecentDividendEntitlements()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entitlements[0].Exact != nil {
+		t.Errorf("exact = %+v, want nil without WithExactDecimals", entitlements[0].Exact)
+	}
+
+	client := newFixtureClient(t, klse.WithExactDecimals())
+	entitlements, err = client.NewAnnouncementRequest().GetRecentDividendEntitlements()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exact := entitlements[1].Exact; exact == nil || exact.Amount.String() != "0.0125" {
+		t.Errorf("exact = %+v, want amount 0.0125", exact)
+	}
+
+	quote := client.NewQuoteResultRequest()
+	results, err := quote.GetQuoteResults(quote.WithBoard(keys.B_ACE_MARKET))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exact := results[0].Exact; exact == nil || exact.Price.String() != "4.85" || exact.FiftyTwoWeek.High.String() != "6.79" {
+		t.Errorf("exact = %+v", exact)
+	}
+	if exact := results[1].Exact; exact == nil || exact.PE.Valid {
+		t.Errorf("exact pe = %+v, want null for \"-\"", exact)
+	}
+
+	prices, err := client.GetStockHistoricalData("7251")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exact := prices[0].Exact; exact == nil || exact.Close.Float64() != prices[0].Close {
+		t.Errorf("exact = %+v, close = %v", exact, prices[0].Close)
+	}
+}
+
+func TestExactDecimalsParseIssue(t *testing.T) {
+	company, err := newFixtureClient(t, klse.WithExactDecimals()).GetCompanyOverview("6947")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if company.Statistic.OHLC.Exact != nil {
+		t.Errorf("statistic exact = %+v, want nil without close", company.Statistic.OHLC.Exact)
+	}
+
+	// the float of "1.2e1" is parsed, the decimal is not.
+	server := pageServer(`<table>` + quoteResultsHead + `<tbody><tr class="list"><td title="A BHD">A</td>` +
+		`<td>0001</td><td>Technology,Main Market</td><td>1.2e1</td><td>2.1%</td></tr></tbody></table>`)
+	defer server.Close()
+	quote := klse.NewClient(klse.WithBaseURL(server.URL), klse.WithExactDecimals()).NewQuoteResultRequest()
+	results, err := quote.GetQuoteResults()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Price != 12 || results[0].Exact.Price.Valid || results[0].Report.Valid("price") {
+		t.Errorf("results = %+v, want the price issue of the decimal", results)
+	}
+	if !results[0].Report.Valid("changes") {
+		t.Errorf("report = %v", results[0].Report)
+	}
+
+	// the decimal is not parsed without WithExactDecimals.
+	results, err = klse.NewClient(klse.WithBaseURL(server.URL)).NewQuoteResultRequest().GetQuoteResults()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Price != 12 || results[0].Exact != nil || len(results[0].Report) != 0 {
+		t.Errorf("results = %+v, want no issue without exact decimals", results)
+	}
+}
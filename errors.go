@@ -0,0 +1,95 @@
+package klse
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned when klsescreener response 404, eg unknown stock code.
+	ErrNotFound = errors.New("klse: not found")
+	// ErrRateLimited is returned when klsescreener response 429 too many requests.
+	ErrRateLimited = errors.New("klse: rate limited")
+	// ErrParse is returned when the response can't be parsed.
+	ErrParse = errors.New("klse: parse error")
+	// ErrUpstreamStatus is returned when klsescreener response non 2xx status.
+	ErrUpstreamStatus = errors.New("klse: unexpected upstream status")
+	// ErrSchemaDrift is returned when the columns of a scraped table are
+	// changed by klsescreener, see *DriftError.
+	ErrSchemaDrift = errors.New("klse: schema drift")
+	// ErrNoValue is the error of ParseIssue when the cell is empty or "-".
+	ErrNoValue = errors.New("klse: no value")
+	// ErrSkippedRows is returned with the other rows when some rows of the
+	// chart data can't be parsed, see *SkippedRowsError.
+	ErrSkippedRows = errors.New("klse: skipped rows")
+	// ErrNoChartData is returned when the chart page has no data array,
+	// eg the code is delisted or klsescreener responses the error page.
+	ErrNoChartData = errors.New("klse: no chart data")
+	// ErrNotIntraday is returned when the bars of the intraday chart are not the
+	// minute bars of one trading day, eg the daily bars.
+	ErrNotIntraday = errors.New("klse: not intraday chart data")
+	// ErrInvalidPeriod is returned when the period of WithPeriod is not one
+	// of the preset periods, eg "2y".
+	ErrInvalidPeriod = errors.New("klse: invalid period")
+)
+
+// StatusError is the error for non 2xx response, it wraps the HTTP status.
+// errors.Is(err, ErrUpstreamStatus) is always true, 404 is ErrNotFound
+// and 429 is ErrRateLimited.
+type StatusError struct {
+	URL        string
+	StatusCode int
+	Status     string
+	RetryAfter time.Duration // from Retry-After header, 0 if not set.
+}
+
+// Error is to implement error interface.
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: %s : %s", ErrUpstreamStatus, e.URL, e.Status)
+}
+
+// Is is to match the error with ErrUpstreamStatus, ErrNotFound and ErrRateLimited.
+func (e *StatusError) Is(target error) bool {
+	switch target {
+	case ErrUpstreamStatus:
+		return true
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// DriftError is the error for a scraped table which column headers are
+// not the expected ones. The columns are mapped by the header names, so
+// the fields of Missing headers are left empty and the Unknown headers are
+// ignored. The relabelled headers at the position of the missing ones, and
+// the table without header, are mapped by position and listed in
+// MappedByPosition, the results are returned with the error so the
+// values should be checked. errors.Is(err, ErrSchemaDrift) is always true.
+type DriftError struct {
+	URL              string
+	Table            string
+	Missing          []string
+	Unknown          []string
+	MappedByPosition []string // eg "PEG as PE", or the expected headers of the table without header.
+}
+
+// Error is to implement error interface.
+func (e *DriftError) Error() string {
+	return fmt.Sprintf("%s: %s : table %s missing %q unknown %q mapped by position %q",
+		ErrSchemaDrift, e.URL, e.Table, e.Missing, e.Unknown, e.MappedByPosition)
+}
+
+// Is is to match the error with ErrSchemaDrift.
+func (e *DriftError) Is(target error) bool {
+	return target == ErrSchemaDrift
+}
+
+// newParseError is to wrap the parsing error with ErrParse.
+func newParseError(url string, err error) error {
+	return fmt.Errorf("%w: %s : %s", ErrParse, url, err.Error())
+}
@@ -2,8 +2,9 @@ module github.com/kokweikhong/klsescreener-scraper
 
 go 1.18
 
+require github.com/PuerkitoBio/goquery v1.8.0
+
 require (
-	github.com/PuerkitoBio/goquery v1.8.0 // indirect
 	github.com/andybalholm/cascadia v1.3.1 // indirect
 	golang.org/x/net v0.0.0-20210916014120-12bc252f5db8 // indirect
 )
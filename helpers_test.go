@@ -0,0 +1,74 @@
+package klse_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+)
+
+// testServer is the test server of the pages of the tests, the requests,
+// the maximum concurrent requests and the requested paths are recorded.
+type testServer struct {
+	*httptest.Server
+	mu            sync.Mutex
+	paths         []string
+	requests      int32
+	concurrent    int32
+	maxConcurrent int32
+}
+
+// newTestServer is to start the test server which responses the page of
+// the request.
+func newTestServer(page func(r *http.Request) string) *testServer {
+	s := &testServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&s.requests, 1)
+		n := atomic.AddInt32(&s.concurrent, 1)
+		defer atomic.AddInt32(&s.concurrent, -1)
+		for max := atomic.LoadInt32(&s.maxConcurrent); n > max; max = atomic.LoadInt32(&s.maxConcurrent) {
+			if atomic.CompareAndSwapInt32(&s.maxConcurrent, max, n) {
+				break
+			}
+		}
+		s.mu.Lock()
+		s.paths = append(s.paths, r.URL.Path)
+		s.mu.Unlock()
+		w.Write([]byte(page(r)))
+	}))
+	return s
+}
+
+// pageServer is the test server which responses the page to every request.
+func pageServer(page string) *testServer {
+	return newTestServer(func(*http.Request) string { return page })
+}
+
+// chartPage is the chart page of the rows of the data array, eg
+// "[1656288000000,1,2,0.5,1.5,100],".
+func chartPage(data string) string {
+	return "<script>\n$(function () {\n  var data = [\n" + data + "\n  ];\n" +
+		"  Highcharts.stockChart('chart', {series: [{data: data}]});\n});\n</script>"
+}
+
+// chartPageServer is the test server of the chart page of the rows.
+func chartPageServer(data string) *testServer {
+	return pageServer(chartPage(data))
+}
+
+// Requests is the number of the requests.
+func (s *testServer) Requests() int {
+	return int(atomic.LoadInt32(&s.requests))
+}
+
+// MaxConcurrent is the maximum number of the concurrent requests.
+func (s *testServer) MaxConcurrent() int {
+	return int(atomic.LoadInt32(&s.maxConcurrent))
+}
+
+// Paths is the requested paths in order.
+func (s *testServer) Paths() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.paths...)
+}
@@ -1,19 +1,16 @@
 package klse
 
 import (
+	"context"
 	"fmt"
-	"io/ioutil"
 	"net/http"
-	"regexp"
 	"sort"
-	"strconv"
-	"strings"
-	"sync"
 	"time"
 
 	"github.com/kokweikhong/klsescreener-scraper/keys"
 )
 
+// OHLC is the daily price and volume, Date is the midnight MYT of the trading date.
 type OHLC struct {
 	Date   time.Time
 	Open   float64
@@ -21,160 +18,196 @@ type OHLC struct {
 	Low    float64
 	Close  float64
 	Volume int
+	Exact  *ExactOHLC `json:",omitempty"` // with WithExactDecimals only.
 }
 
-// GetStockHistoricalData is to get 10 years individual stock price data.
-func GetStockHistoricalData(code string) ([]*OHLC, error) {
-	prices := []*OHLC{}
-	url := fmt.Sprintf("https://www.klsescreener.com/v2/stocks/chart/%s/embedded/10y", code)
-	resp := newRequest(http.MethodGet, url, nil)
-	defer resp.Body.Close()
-	body, err := ioutil.ReadAll(resp.Body)
+// ExactOHLC is the exact decimal prices of OHLC.
+type ExactOHLC struct {
+	Open  Decimal
+	High  Decimal
+	Low   Decimal
+	Close Decimal
+}
+
+// TradingDate is the trading date of the daily bar.
+func (o *OHLC) TradingDate() TradingDate {
+	return NewTradingDate(o.Date)
+}
+
+// ohlcDate is the date of the daily bar.
+func ohlcDate(o *OHLC) time.Time {
+	return o.Date
+}
+
+// tradingDateOfUnixMilli is the trading date in MYT of the chart timestamp.
+func tradingDateOfUnixMilli(msec int64) TradingDate {
+	return NewTradingDate(time.UnixMilli(msec))
+}
+
+// exactOHLC is to parse the exact decimal prices of the row of the field,
+// nil when the exact decimals are not enabled.
+func (p *parser) exactOHLC(field, open, high, low, close string) *ExactOHLC {
+	if !p.exact {
+		return nil
+	}
+	return &ExactOHLC{
+		Open:  p.decimal(field+"open", open),
+		High:  p.decimal(field+"high", high),
+		Low:   p.decimal(field+"low", low),
+		Close: p.decimal(field+"close", close),
+	}
+}
+
+// GetStockHistoricalData is to get individual stock price data of the period,
+// 10 years by default, with the default client.
+func GetStockHistoricalData(code string, opts ...HistoricalOption) ([]*OHLC, error) {
+	return DefaultClient.GetStockHistoricalData(code, opts...)
+}
+
+// GetStockHistoricalDataContext is GetStockHistoricalData with context
+// using the default client.
+func GetStockHistoricalDataContext(ctx context.Context, code string, opts ...HistoricalOption) ([]*OHLC, error) {
+	return DefaultClient.GetStockHistoricalDataContext(ctx, code, opts...)
+}
+
+// GetStockHistoricalData is to get individual stock price data of the period,
+// 10 years by default, eg WithPeriod(Period1M) or WithDateRange(from, to).
+func (c *Client) GetStockHistoricalData(code string, opts ...HistoricalOption) ([]*OHLC, error) {
+	return c.GetStockHistoricalDataContext(context.Background(), code, opts...)
+}
+
+// GetStockHistoricalDataContext is GetStockHistoricalData with context,
+// the parsing will stop when the context is cancelled.
+func (c *Client) GetStockHistoricalDataContext(ctx context.Context, code string, opts ...HistoricalOption) ([]*OHLC, error) {
+	period, err := c.newHistoricalRange(opts)
 	if err != nil {
-		logWarning.Printf("%s : %s", url, err.Error())
 		return nil, err
 	}
-	data := getHistoricalDataFromJS(string(body))
-	for k, d := range data {
-		price := &OHLC{}
-		if len(d) < 2 {
-			continue
-		}
-		splitData := strings.Split(d[1], ",")
-		if len(splitData) != 6 {
-			continue
-		}
-		dateUnix, err := strconv.ParseInt(splitData[0], 10, 64)
-		if err != nil {
-			logError.Println(err)
-			continue
-		}
-		price.Date = time.UnixMilli(dateUnix)
-		price.Open = convertStringToFloat64(splitData[1], 3)
-		price.High = convertStringToFloat64(splitData[2], 3)
-		price.Low = convertStringToFloat64(splitData[3], 4)
-		price.Close = convertStringToFloat64(splitData[4], 5)
-		price.Volume = int(convertStringToFloat64(splitData[5], 0))
-		prices = append(prices, price)
-		logInfo.Printf("getting %d data : %v", k+1, price)
+	path := fmt.Sprintf("/v2/stocks/chart/%s/embedded/%s", code, period.period)
+	p := c.newParser(ctx, c.baseURL+path, Field{Key: "code", Value: code})
+	resp, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
 	}
-	return prices, nil
+	defer resp.Body.Close()
+	prices, err := readChart(p, resp.Body, 6, p.parseChartOHLC)
+	if prices == nil {
+		return nil, err
+	}
+	return trimHistorical(prices, period, ohlcDate), err
 }
 
-// GetBursaIndexHistoricalData
-func GetBursaIndexHistoricalData(bursaIndex keys.BURSA_INDEX) []*OHLC {
-	ohlcs := []*OHLC{}
-	url := "https://www.klsescreener.com/v2/stocks/chart/" + string(bursaIndex)
-	resp := newRequest(http.MethodGet, url, nil)
-	defer resp.Body.Close()
-	body, err := ioutil.ReadAll(resp.Body)
+// parseChartOHLC is to parse the row of date, open, high, low, close and volume.
+func (p *parser) parseChartOHLC(field string, values []string) *OHLC {
+	return &OHLC{
+		Date:   p.chartDate(field+"date", values[0]),
+		Open:   p.float(field+"open", values[1], 3),
+		High:   p.float(field+"high", values[2], 3),
+		Low:    p.float(field+"low", values[3], 4),
+		Close:  p.float(field+"close", values[4], 5),
+		Volume: p.integer(field+"volume", values[5]),
+		Exact:  p.exactOHLC(field, values[1], values[2], values[3], values[4]),
+	}
+}
+
+// GetBursaIndexHistoricalData is to get individual bursa index historical data
+// with the default client.
+func GetBursaIndexHistoricalData(bursaIndex keys.BURSA_INDEX, opts ...HistoricalOption) ([]*OHLC, error) {
+	return DefaultClient.GetBursaIndexHistoricalData(bursaIndex, opts...)
+}
+
+// GetBursaIndexHistoricalDataContext is GetBursaIndexHistoricalData with context
+// using the default client.
+func GetBursaIndexHistoricalDataContext(ctx context.Context, bursaIndex keys.BURSA_INDEX, opts ...HistoricalOption) ([]*OHLC, error) {
+	return DefaultClient.GetBursaIndexHistoricalDataContext(ctx, bursaIndex, opts...)
+}
+
+// GetBursaIndexHistoricalData is to get individual bursa index historical data.
+func (c *Client) GetBursaIndexHistoricalData(bursaIndex keys.BURSA_INDEX, opts ...HistoricalOption) ([]*OHLC, error) {
+	return c.GetBursaIndexHistoricalDataContext(context.Background(), bursaIndex, opts...)
+}
+
+// GetBursaIndexHistoricalDataContext is GetBursaIndexHistoricalData with context,
+// the chart of bursa index has no period and the data are trimmed to the period.
+func (c *Client) GetBursaIndexHistoricalDataContext(ctx context.Context, bursaIndex keys.BURSA_INDEX, opts ...HistoricalOption) ([]*OHLC, error) {
+	period, err := c.newHistoricalRange(opts)
 	if err != nil {
-		logWarning.Printf("%s : %s", url, err.Error())
-		return ohlcs
+		return nil, err
 	}
-	data := getHistoricalDataFromJS(string(body))
-	wg := sync.WaitGroup{}
-	mutex := sync.Mutex{}
-	wg.Add(len(data))
-	for i := 0; i < len(data); i++ {
-		go func(i int) {
-			if len(data[i]) < 2 {
-				return
-			}
-			splitData := strings.Split(data[i][1], ",")
-			if len(splitData) != 6 {
-				return
-			}
-			ohlc := &OHLC{}
-			dateUnix, err := strconv.ParseInt(splitData[0], 10, 64)
-			if err != nil {
-				logError.Println(err)
-				return
-			}
-			mutex.Lock()
-			ohlc.Date = time.UnixMilli(dateUnix)
-			ohlc.Open = convertStringToFloat64(splitData[1], 3)
-			ohlc.High = convertStringToFloat64(splitData[2], 3)
-			ohlc.Low = convertStringToFloat64(splitData[3], 4)
-			ohlc.Close = convertStringToFloat64(splitData[4], 5)
-			ohlc.Volume = int(convertStringToFloat64(splitData[5], 0))
-			ohlcs = append(ohlcs, ohlc)
-			mutex.Unlock()
-			wg.Done()
-		}(i)
+	path := "/v2/stocks/chart/" + string(bursaIndex)
+	p := c.newParser(ctx, c.baseURL+path, Field{Key: "code", Value: bursaIndex})
+	resp, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	ohlcs, err := readChart(p, resp.Body, 6, p.parseChartOHLC)
+	if ohlcs == nil {
+		return nil, err
 	}
-	wg.Wait()
 	// sort the results based on time.
-	sort.Slice(ohlcs, func(i, j int) bool {
+	sort.SliceStable(ohlcs, func(i, j int) bool {
 		return ohlcs[i].Date.Before(ohlcs[j].Date)
 	})
-	return ohlcs
+	return trimHistorical(ohlcs, period, ohlcDate), err
 }
 
-// MarketHistoricalData is the market index historical data structure.
+// MarketHistoricalData is the market index historical data structure,
+// Date is the midnight MYT of the trading date.
 type MarketHistoricalData struct {
 	Date   time.Time `json:"date"`
 	Close  float64   `json:"close"`
 	Volume int       `json:"volume"`
 }
 
+// TradingDate is the trading date of the daily data.
+func (m *MarketHistoricalData) TradingDate() TradingDate {
+	return NewTradingDate(m.Date)
+}
+
+// GetMarketIndexHistoricalData is to get individual market index historical data
+// with the default client.
+func GetMarketIndexHistoricalData(index keys.MARKET_INDEX, opts ...HistoricalOption) ([]*MarketHistoricalData, error) {
+	return DefaultClient.GetMarketIndexHistoricalData(index, opts...)
+}
+
+// GetMarketIndexHistoricalDataContext is GetMarketIndexHistoricalData with context
+// using the default client.
+func GetMarketIndexHistoricalDataContext(ctx context.Context, index keys.MARKET_INDEX, opts ...HistoricalOption) ([]*MarketHistoricalData, error) {
+	return DefaultClient.GetMarketIndexHistoricalDataContext(ctx, index, opts...)
+}
+
 // GetMarketIndexHistoricalData is to get individual market index historical data.
-func GetMarketIndexHistoricalData(index keys.MARKET_INDEX) []*MarketHistoricalData {
-	results := []*MarketHistoricalData{}
-	url := fmt.Sprintf("https://www.klsescreener.com/v2/markets/historical_period/%v/10y", index)
-	resp := newRequest(http.MethodGet, url, nil)
-	defer resp.Body.Close()
-	body, err := ioutil.ReadAll(resp.Body)
+func (c *Client) GetMarketIndexHistoricalData(index keys.MARKET_INDEX, opts ...HistoricalOption) ([]*MarketHistoricalData, error) {
+	return c.GetMarketIndexHistoricalDataContext(context.Background(), index, opts...)
+}
+
+// GetMarketIndexHistoricalDataContext is GetMarketIndexHistoricalData with context.
+func (c *Client) GetMarketIndexHistoricalDataContext(ctx context.Context, index keys.MARKET_INDEX, opts ...HistoricalOption) ([]*MarketHistoricalData, error) {
+	period, err := c.newHistoricalRange(opts)
 	if err != nil {
-		logWarning.Printf("%s : %s", url, err.Error())
-		return results
+		return nil, err
 	}
-	data := getHistoricalDataFromJS(string(body))
-	wg := sync.WaitGroup{}
-	mutex := sync.Mutex{}
-	wg.Add(len(data))
-	for i := 0; i < len(data); i++ {
-		go func(i int) {
-			if len(data[i]) < 2 {
-				return
-			}
-			splitData := strings.Split(data[i][1], ",")
-			if len(splitData) != 3 {
-				return
-			}
-			result := &MarketHistoricalData{}
-			dateUnix, err := strconv.ParseInt(splitData[0], 10, 64)
-			if err != nil {
-				logError.Println(err)
-				return
-			}
-			result.Date = time.UnixMilli(dateUnix)
-			result.Close = convertStringToFloat64(removeAllSpaces(splitData[1], ""), 4)
-			result.Volume = int(convertStringToFloat64(removeAllSpaces(splitData[2], ""), 0))
-			mutex.Lock()
-			logInfo.Printf("getting %d data: %v\n", i, result)
-			results = append(results, result)
-			mutex.Unlock()
-			wg.Done()
-		}(i)
+	path := fmt.Sprintf("/v2/markets/historical_period/%v/%s", index, period.period)
+	p := c.newParser(ctx, c.baseURL+path, Field{Key: "code", Value: index})
+	resp, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	results, err := readChart(p, resp.Body, 3, func(field string, values []string) *MarketHistoricalData {
+		return &MarketHistoricalData{
+			Date:   p.chartDate(field+"date", values[0]),
+			Close:  p.float(field+"close", values[1], 4),
+			Volume: p.integer(field+"volume", values[2]),
+		}
+	})
+	if results == nil {
+		return nil, err
 	}
-	wg.Wait()
-
 	// sort the results by time.
-	sort.Slice(results, func(i, j int) bool {
+	sort.SliceStable(results, func(i, j int) bool {
 		return results[i].Date.Before(results[j].Date)
 	})
-	return results
-}
-
-// getHistoricalDataFromJS is to retrieve all the data from
-// javascript web source.
-func getHistoricalDataFromJS(body string) [][]string {
-	regexpSpaces := regexp.MustCompile(`\s+`)               // get all the spaces
-	bodyString := regexpSpaces.ReplaceAllString(body, "")   // replace all spaces with none
-	regexpData := regexp.MustCompile(`data=\[(.*?),\];`)    // get the data = [ ] from javascript
-	raw := regexpData.FindStringSubmatch(bodyString)        // find the data from web source
-	regexpIndividualData := regexp.MustCompile(`\[(.*?)\]`) // get the individual list
-	dataList := regexpIndividualData.FindAllStringSubmatch(raw[1], -1)
-	return dataList
+	return trimHistorical(results, period, func(m *MarketHistoricalData) time.Time { return m.Date }), err
 }
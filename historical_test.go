@@ -1,26 +1,59 @@
 package klse_test
 
 import (
-	"encoding/json"
-	"fmt"
 	"testing"
+	"time"
 
 	klse "github.com/kokweikhong/klsescreener-scraper"
 	"github.com/kokweikhong/klsescreener-scraper/keys"
 )
 
 func TestGetStockHistoricalData(t *testing.T) {
-    klse.GetStockHistoricalData("7251")
+	data, err := newFixtureClient(t).GetStockHistoricalData("7251")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 45 {
+		t.Fatalf("data = %d, want 45", len(data))
+	}
+	first := data[0]
+	if !first.Date.Equal(time.Date(2022, 5, 2, 0, 0, 0, 0, klse.MYT)) ||
+		first.TradingDate() != (klse.TradingDate{Year: 2022, Month: time.May, Day: 2}) ||
+		first.Open != 0.25 || first.High != 0.275 || first.Low != 0.245 || first.Close != 0.27 || first.Volume != 700000 {
+		t.Errorf("first = %+v", first)
+	}
+	for i := 1; i < len(data); i++ {
+		if !data[i].Date.After(data[i-1].Date) {
+			t.Fatalf("data[%d] %v is not after %v", i, data[i].Date, data[i-1].Date)
+		}
+	}
 }
 
 func TestGetBursaIndexHistoricalData(t *testing.T) {
-    data := klse.GetBursaIndexHistoricalData(keys.PROPERTY)
-    b, _ := json.MarshalIndent(data, "", "  ")
-    fmt.Println(string(b))
+	data, err := newFixtureClient(t).GetBursaIndexHistoricalData(keys.PROPERTY)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 4 {
+		t.Fatalf("data = %d, want 4", len(data))
+	}
+	last := data[3]
+	if !last.Date.Equal(time.Date(2022, 6, 30, 0, 0, 0, 0, klse.MYT)) ||
+		last.Open != 635.61 || last.High != 638.99 || last.Low != 634.1 || last.Close != 638.4 || last.Volume != 287654100 {
+		t.Errorf("last = %+v", last)
+	}
 }
 
 func TestGetMarketHistoricalData(t *testing.T) {
-    data := klse.GetMarketIndexHistoricalData(keys.GOLD)
-    b, _ := json.MarshalIndent(data, "", "  ")
-    fmt.Println(string(b))
+	data, err := newFixtureClient(t).GetMarketIndexHistoricalData(keys.GOLD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 4 {
+		t.Fatalf("data = %d, want 4", len(data))
+	}
+	first := data[0]
+	if !first.Date.Equal(time.Date(2022, 6, 27, 0, 0, 0, 0, klse.MYT)) || first.Close != 1822.8 || first.Volume != 1523 {
+		t.Errorf("first = %+v", first)
+	}
 }
@@ -0,0 +1,171 @@
+package indicators
+
+import (
+	"math"
+
+	klse "github.com/kokweikhong/klsescreener-scraper"
+)
+
+// RSIStream is the streaming relative strength index with Wilder's smoothing,
+// the first average gain and loss are the simple average of the first
+// period changes.
+type RSIStream struct {
+	gain    wilder
+	loss    wilder
+	prev    float64
+	started bool
+}
+
+// NewRSIStream is to initialise the relative strength index of the period, eg 14.
+func NewRSIStream(period int) *RSIStream {
+	mustPeriod("RSI", period)
+	return &RSIStream{gain: wilder{period: float64(period)}, loss: wilder{period: float64(period)}}
+}
+
+// Update is to add the close and get the RSI from 0 to 100, ok is false and
+// the RSI is NaN before the period changes, ie period + 1 closes.
+func (s *RSIStream) Update(close float64) (rsi float64, ok bool) {
+	change := close - s.prev
+	s.prev = close
+	if !s.started {
+		s.started = true
+		return math.NaN(), false
+	}
+	gain, ok := s.gain.update(math.Max(change, 0))
+	loss, _ := s.loss.update(math.Max(-change, 0))
+	switch {
+	case !ok:
+		return math.NaN(), false
+	case gain == 0 && loss == 0:
+		return 50, true
+	case loss == 0:
+		return 100, true
+	}
+	return 100 - 100/(1+gain/loss), true
+}
+
+// RSI is the relative strength index of the closes.
+func RSI(closes []float64, period int) []float64 {
+	return series(closes, NewRSIStream(period).Update)
+}
+
+// MACDValue is the MACD line, signal line and histogram.
+type MACDValue struct {
+	MACD      float64 // fast EMA - slow EMA.
+	Signal    float64 // EMA of MACD.
+	Histogram float64 // MACD - Signal.
+}
+
+// MACDStream is the streaming moving average convergence divergence.
+type MACDStream struct {
+	fast   *EMAStream
+	slow   *EMAStream
+	signal *EMAStream
+}
+
+// NewMACDStream is to initialise the MACD of the fast, slow and signal
+// periods, eg 12, 26, 9.
+func NewMACDStream(fast, slow, signal int) *MACDStream {
+	return &MACDStream{fast: NewEMAStream(fast), slow: NewEMAStream(slow), signal: NewEMAStream(signal)}
+}
+
+// Update is to add the close and get the MACD, ok is false before the signal
+// line has enough data, the MACD line is available after the slow period.
+func (s *MACDStream) Update(close float64) (value MACDValue, ok bool) {
+	fast, _ := s.fast.Update(close)
+	slow, slowOK := s.slow.Update(close)
+	value = MACDValue{MACD: math.NaN(), Signal: math.NaN(), Histogram: math.NaN()}
+	if !slowOK {
+		return value, false
+	}
+	value.MACD = fast - slow
+	value.Signal, ok = s.signal.Update(value.MACD)
+	value.Histogram = value.MACD - value.Signal
+	return value, ok
+}
+
+// MACDSeries is the MACD of the series.
+type MACDSeries struct {
+	MACD      []float64
+	Signal    []float64
+	Histogram []float64
+}
+
+// MACD is the moving average convergence divergence of the closes.
+func MACD(closes []float64, fast, slow, signal int) MACDSeries {
+	stream := NewMACDStream(fast, slow, signal)
+	results := MACDSeries{
+		MACD:      make([]float64, len(closes)),
+		Signal:    make([]float64, len(closes)),
+		Histogram: make([]float64, len(closes)),
+	}
+	for i, close := range closes {
+		value, _ := stream.Update(close)
+		results.MACD[i], results.Signal[i], results.Histogram[i] = value.MACD, value.Signal, value.Histogram
+	}
+	return results
+}
+
+// StochasticValue is the stochastic oscillator from 0 to 100.
+type StochasticValue struct {
+	K float64 // (close - lowest low) / (highest high - lowest low) x 100.
+	D float64 // simple moving average of K.
+}
+
+// StochasticStream is the streaming stochastic oscillator.
+type StochasticStream struct {
+	highs window
+	lows  window
+	d     *SMAStream
+}
+
+// NewStochasticStream is to initialise the stochastic oscillator of the K
+// and D periods, eg 14, 3.
+func NewStochasticStream(kPeriod, dPeriod int) *StochasticStream {
+	mustPeriod("Stochastic", kPeriod)
+	return &StochasticStream{
+		highs: window{period: kPeriod},
+		lows:  window{period: kPeriod},
+		d:     NewSMAStream(dPeriod),
+	}
+}
+
+// Update is to add the bar and get the stochastic oscillator, ok is false
+// before D has enough data, K is available after the K period bars.
+// K is 50 when the highest high is the lowest low.
+func (s *StochasticStream) Update(bar *klse.OHLC) (value StochasticValue, ok bool) {
+	s.highs.push(bar.High)
+	s.lows.push(bar.Low)
+	value = StochasticValue{K: math.NaN(), D: math.NaN()}
+	if !s.highs.full() {
+		return value, false
+	}
+	highest, lowest := s.highs.values[0], s.lows.values[0]
+	for i := range s.highs.values {
+		highest = math.Max(highest, s.highs.values[i])
+		lowest = math.Min(lowest, s.lows.values[i])
+	}
+	value.K = 50
+	if highest != lowest {
+		value.K = (bar.Close - lowest) / (highest - lowest) * 100
+	}
+	value.D, ok = s.d.Update(value.K)
+	return value, ok
+}
+
+// StochasticSeries is the stochastic oscillator of the series.
+type StochasticSeries struct {
+	K []float64
+	D []float64
+}
+
+// Stochastic is the stochastic oscillator of the bars.
+func Stochastic(bars []*klse.OHLC, kPeriod, dPeriod int) StochasticSeries {
+	stream := NewStochasticStream(kPeriod, dPeriod)
+	results := StochasticSeries{K: make([]float64, len(bars)), D: make([]float64, len(bars))}
+	for i, bar := range bars {
+		value, _ := stream.Update(bar)
+		results.K[i], results.D[i] = value.K, value.D
+	}
+	return results
+}
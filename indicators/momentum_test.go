@@ -0,0 +1,110 @@
+package indicators_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kokweikhong/klsescreener-scraper/indicators"
+	"github.com/kokweikhong/klsescreener-scraper/internal/fixture"
+)
+
+func TestRSI(t *testing.T) {
+	closes := []float64{10, 11, 12, 11, 13}
+	// first gain 4 / 4 = 1, loss 1 / 4 = 0.25, RS 4.
+	assertSeries(t, "RSI", indicators.RSI(closes, 4), []float64{nan, nan, nan, nan, 80})
+	// gain (1 x 3 + 0) / 4 = 0.75, loss (0.25 x 3 + 1) / 4 = 0.4375.
+	closes = append(closes, 12)
+	got := indicators.RSI(closes, 4)
+	if want := 100 - 100/(1+0.75/0.4375); !near(got[5], want) {
+		t.Errorf("RSI[5] = %v, want %v", got[5], want)
+	}
+	if got := indicators.RSI([]float64{1, 2, 3}, 2); got[2] != 100 {
+		t.Errorf("RSI of gains = %v, want 100", got[2])
+	}
+	if got := indicators.RSI([]float64{1, 1, 1}, 2); got[2] != 50 {
+		t.Errorf("RSI of flat = %v, want 50", got[2])
+	}
+}
+
+// TestRSIReference is to compare with the RSI(14) example of the StockCharts
+// ChartSchool, the published table is of the rounded averages so the values
+// are within 0.1.
+func TestRSIReference(t *testing.T) {
+	closes := []float64{
+		44.34, 44.09, 44.15, 43.61, 44.33, 44.83, 45.10, 45.42, 45.84, 46.08, 45.89,
+		46.03, 45.61, 46.28, 46.28, 46.00, 46.03, 46.41, 46.22, 45.64, 46.21, 46.25,
+		45.71, 46.45, 45.78, 45.35, 44.03, 44.18, 44.22, 44.57, 43.42, 42.66, 43.13,
+	}
+	want := []float64{
+		70.53, 66.32, 66.55, 69.41, 66.36, 57.97, 62.93, 63.26, 56.06, 62.38,
+		54.71, 50.42, 39.99, 41.46, 41.87, 45.46, 37.30, 33.08, 37.77,
+	}
+	rsi := indicators.RSI(closes, 14)
+	for i, want := range want {
+		if got := rsi[i+14]; math.Abs(got-want) > 0.1 {
+			t.Errorf("RSI[%d] = %.2f, want %.2f", i+14, got, want)
+		}
+	}
+}
+
+// TestRSIWithCompanyStatistic is to compare with the RSI(14) of the recorded
+// stock page and the chart of the same day.
+func TestRSIWithCompanyStatistic(t *testing.T) {
+	handwritten, err := fixture.Handwritten("../testdata/fixtures")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range handwritten {
+		if name == "GET_v2_stocks_view_7251.http" || name == "GET_v2_stocks_chart_7251_embedded_10y.http" {
+			t.Skipf("%s is hand-written, record it with go test ./... -record", name)
+		}
+	}
+	client := fixture.NewClient("../testdata/fixtures")
+	data, err := client.GetStockHistoricalData("7251")
+	if err != nil {
+		t.Fatal(err)
+	}
+	company, err := client.GetCompanyOverview("7251")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the statistic is of the last bar of the chart.
+	last, statistic := data[len(data)-1], company.Statistic.OHLC
+	if statistic.Open != last.Open || statistic.High != last.High || statistic.Low != last.Low {
+		t.Fatalf("statistic %+v is not the last bar %+v", statistic, last)
+	}
+	rsi := indicators.RSI(indicators.Closes(data), 14)
+	if got := math.Round(rsi[len(rsi)-1]*10) / 10; got != company.Statistic.RSI14 {
+		t.Errorf("RSI(14) = %v, statistic RSI(14) = %v", got, company.Statistic.RSI14)
+	}
+}
+
+func TestMACD(t *testing.T) {
+	closes := []float64{1, 2, 3, 4, 5, 6, 7, 8}
+	macd := indicators.MACD(closes, 2, 4, 3)
+	// EMA(2) - EMA(4) of the linear closes is 1 after the slow period.
+	assertSeries(t, "MACD", macd.MACD, []float64{nan, nan, nan, 1, 1, 1, 1, 1})
+	assertSeries(t, "Signal", macd.Signal, []float64{nan, nan, nan, nan, nan, 1, 1, 1})
+	assertSeries(t, "Histogram", macd.Histogram, []float64{nan, nan, nan, nan, nan, 0, 0, 0})
+
+	stream := indicators.NewMACDStream(2, 4, 3)
+	for i, close := range closes {
+		value, ok := stream.Update(close)
+		if !near(value.MACD, macd.MACD[i]) || ok == math.IsNaN(macd.Signal[i]) {
+			t.Errorf("MACD stream[%d] = %+v, %v", i, value, ok)
+		}
+	}
+}
+
+func TestStochastic(t *testing.T) {
+	data := bars(
+		[]float64{10, 12, 11, 13},
+		[]float64{8, 9, 9, 10},
+		[]float64{9, 11, 10, 13},
+		[]int{1, 1, 1, 1},
+	)
+	stochastic := indicators.Stochastic(data, 3, 2)
+	// K[2] = (10 - 8) / (12 - 8), K[3] = (13 - 9) / (13 - 9).
+	assertSeries(t, "K", stochastic.K, []float64{nan, nan, 50, 100})
+	assertSeries(t, "D", stochastic.D, []float64{nan, nan, nan, 75})
+}
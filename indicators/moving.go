@@ -0,0 +1,178 @@
+// Package indicators is to calculate the technical indicators of the
+// historical data of klse, eg RSI, MACD, Bollinger Bands.
+//
+// The functions of the series return the values of the same length as the
+// input, the values are NaN before the indicator has enough data. Every
+// indicator has the streaming variant to update with the new value one by
+// one, eg NewRSIStream(14). The constructors panic when the period is not
+// positive.
+package indicators
+
+import (
+	"fmt"
+	"math"
+
+	klse "github.com/kokweikhong/klsescreener-scraper"
+)
+
+// Closes is the close prices of the bars.
+func Closes(bars []*klse.OHLC) []float64 {
+	closes := make([]float64, len(bars))
+	for i, bar := range bars {
+		closes[i] = bar.Close
+	}
+	return closes
+}
+
+// mustPeriod is to panic when the period is not positive.
+func mustPeriod(name string, period int) {
+	if period < 1 {
+		panic(fmt.Sprintf("indicators: %s period %d is not positive", name, period))
+	}
+}
+
+// window is the latest values up to the period, oldest first.
+type window struct {
+	period int
+	values []float64
+}
+
+// push is to add the value and drop the oldest one when it is full.
+func (w *window) push(value float64) {
+	if len(w.values) == w.period {
+		copy(w.values, w.values[1:])
+		w.values = w.values[:w.period-1]
+	}
+	w.values = append(w.values, value)
+}
+
+// full is to check the window has the values of the period.
+func (w *window) full() bool {
+	return len(w.values) == w.period
+}
+
+// series is to update the stream with the values and collect the results.
+func series(values []float64, update func(float64) (float64, bool)) []float64 {
+	results := make([]float64, len(values))
+	for i, value := range values {
+		results[i], _ = update(value)
+	}
+	return results
+}
+
+// SMAStream is the streaming simple moving average.
+type SMAStream struct {
+	window window
+}
+
+// NewSMAStream is to initialise the simple moving average of the period.
+func NewSMAStream(period int) *SMAStream {
+	mustPeriod("SMA", period)
+	return &SMAStream{window: window{period: period}}
+}
+
+// Update is to add the value and get the average of the latest period values,
+// ok is false and the average is NaN before the period values.
+func (s *SMAStream) Update(value float64) (average float64, ok bool) {
+	s.window.push(value)
+	if !s.window.full() {
+		return math.NaN(), false
+	}
+	sum := 0.0
+	for _, v := range s.window.values {
+		sum += v
+	}
+	return sum / float64(s.window.period), true
+}
+
+// SMA is the simple moving average of the values.
+func SMA(values []float64, period int) []float64 {
+	return series(values, NewSMAStream(period).Update)
+}
+
+// EMAStream is the streaming exponential moving average, the first average
+// is the simple moving average of the first period values.
+type EMAStream struct {
+	alpha float64
+	seed  *SMAStream
+	value float64
+	ready bool
+}
+
+// NewEMAStream is to initialise the exponential moving average of the period,
+// the smoothing is 2 / (period + 1).
+func NewEMAStream(period int) *EMAStream {
+	mustPeriod("EMA", period)
+	return &EMAStream{alpha: 2 / float64(period+1), seed: NewSMAStream(period)}
+}
+
+// Update is to add the value and get the average, ok is false and the
+// average is NaN before the period values.
+func (s *EMAStream) Update(value float64) (average float64, ok bool) {
+	if !s.ready {
+		s.value, s.ready = s.seed.Update(value)
+		return s.value, s.ready
+	}
+	s.value += s.alpha * (value - s.value)
+	return s.value, true
+}
+
+// EMA is the exponential moving average of the values.
+func EMA(values []float64, period int) []float64 {
+	return series(values, NewEMAStream(period).Update)
+}
+
+// WMAStream is the streaming linearly weighted moving average, the latest
+// value has the weight of period and the oldest has 1.
+type WMAStream struct {
+	window window
+}
+
+// NewWMAStream is to initialise the weighted moving average of the period.
+func NewWMAStream(period int) *WMAStream {
+	mustPeriod("WMA", period)
+	return &WMAStream{window: window{period: period}}
+}
+
+// Update is to add the value and get the weighted average, ok is false and
+// the average is NaN before the period values.
+func (s *WMAStream) Update(value float64) (average float64, ok bool) {
+	s.window.push(value)
+	if !s.window.full() {
+		return math.NaN(), false
+	}
+	sum, weights := 0.0, 0.0
+	for i, v := range s.window.values {
+		sum += v * float64(i+1)
+		weights += float64(i + 1)
+	}
+	return sum / weights, true
+}
+
+// WMA is the weighted moving average of the values.
+func WMA(values []float64, period int) []float64 {
+	return series(values, NewWMAStream(period).Update)
+}
+
+// wilder is the Wilder's smoothing average, the first average is the
+// simple average of the first period values.
+type wilder struct {
+	period float64
+	count  int
+	value  float64
+}
+
+// update is to add the value and get the average, ok is false before the period values.
+func (w *wilder) update(value float64) (float64, bool) {
+	w.count++
+	switch {
+	case float64(w.count) < w.period:
+		w.value += value
+		return math.NaN(), false
+	case float64(w.count) == w.period:
+		w.value = (w.value + value) / w.period
+	default:
+		w.value = (w.value*(w.period-1) + value) / w.period
+	}
+	return w.value, true
+}
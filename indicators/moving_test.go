@@ -0,0 +1,68 @@
+package indicators_test
+
+import (
+	"math"
+	"testing"
+
+	klse "github.com/kokweikhong/klsescreener-scraper"
+	"github.com/kokweikhong/klsescreener-scraper/indicators"
+)
+
+// near is to compare the values within 1e-6, NaN is equal to NaN.
+func near(a, b float64) bool {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.IsNaN(a) && math.IsNaN(b)
+	}
+	return math.Abs(a-b) < 1e-6
+}
+
+// assertSeries is to compare the series with the wanted values.
+func assertSeries(t *testing.T, name string, got, want []float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s = %d values, want %d", name, len(got), len(want))
+	}
+	for i := range want {
+		if !near(got[i], want[i]) {
+			t.Errorf("%s[%d] = %v, want %v", name, i, got[i], want[i])
+		}
+	}
+}
+
+// bars is the bars of the highs, lows, closes and volumes.
+func bars(highs, lows, closes []float64, volumes []int) []*klse.OHLC {
+	results := make([]*klse.OHLC, len(closes))
+	for i := range closes {
+		results[i] = &klse.OHLC{Open: closes[i], High: highs[i], Low: lows[i], Close: closes[i], Volume: volumes[i]}
+	}
+	return results
+}
+
+var nan = math.NaN()
+
+func TestMovingAverages(t *testing.T) {
+	values := []float64{1, 2, 3, 4, 5, 6}
+	assertSeries(t, "SMA", indicators.SMA(values, 3), []float64{nan, nan, 2, 3, 4, 5})
+	assertSeries(t, "EMA", indicators.EMA(values, 3), []float64{nan, nan, 2, 3, 4, 5})
+	assertSeries(t, "EMA", indicators.EMA([]float64{2, 4, 6, 2, 10}, 3), []float64{nan, nan, 4, 3, 6.5})
+	assertSeries(t, "WMA", indicators.WMA([]float64{1, 2, 3, 6}, 3), []float64{nan, nan, 14.0 / 6, 26.0 / 6})
+}
+
+func TestMovingAverageStreams(t *testing.T) {
+	values := []float64{3, 1, 4, 1, 5, 9, 2, 6, 5, 3, 5}
+	want := indicators.EMA(values, 4)
+	stream := indicators.NewEMAStream(4)
+	for i, value := range values {
+		got, ok := stream.Update(value)
+		if !near(got, want[i]) || ok == math.IsNaN(want[i]) {
+			t.Errorf("EMA stream[%d] = %v, %v, want %v", i, got, ok, want[i])
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("period 0 should panic")
+		}
+	}()
+	indicators.NewSMAStream(0)
+}
@@ -0,0 +1,101 @@
+package indicators
+
+import (
+	"math"
+
+	klse "github.com/kokweikhong/klsescreener-scraper"
+)
+
+// ADXValue is the average directional index and the directional indicators.
+type ADXValue struct {
+	ADX     float64
+	PlusDI  float64
+	MinusDI float64
+}
+
+// ADXStream is the streaming average directional index of Wilder, the
+// directional movements start from the second bar.
+type ADXStream struct {
+	period  float64
+	count   int // number of the directional movements.
+	prev    *klse.OHLC
+	tr      float64
+	plusDM  float64
+	minusDM float64
+	adx     wilder
+}
+
+// NewADXStream is to initialise the average directional index of the period, eg 14.
+func NewADXStream(period int) *ADXStream {
+	mustPeriod("ADX", period)
+	return &ADXStream{period: float64(period), adx: wilder{period: float64(period)}}
+}
+
+// Update is to add the bar and get the ADX, ok is false before 2 x period
+// bars, the directional indicators are available after period + 1 bars.
+func (s *ADXStream) Update(bar *klse.OHLC) (value ADXValue, ok bool) {
+	value = ADXValue{ADX: math.NaN(), PlusDI: math.NaN(), MinusDI: math.NaN()}
+	prev := s.prev
+	s.prev = bar
+	if prev == nil {
+		return value, false
+	}
+	up, down := bar.High-prev.High, prev.Low-bar.Low
+	plusDM, minusDM := 0.0, 0.0
+	if up > down && up > 0 {
+		plusDM = up
+	}
+	if down > up && down > 0 {
+		minusDM = down
+	}
+	tr := trueRange(bar, prev.Close)
+
+	// Wilder's smoothing of the sums, the first sums are of the first period movements.
+	s.count++
+	if float64(s.count) <= s.period {
+		s.tr += tr
+		s.plusDM += plusDM
+		s.minusDM += minusDM
+		if float64(s.count) < s.period {
+			return value, false
+		}
+	} else {
+		s.tr = s.tr - s.tr/s.period + tr
+		s.plusDM = s.plusDM - s.plusDM/s.period + plusDM
+		s.minusDM = s.minusDM - s.minusDM/s.period + minusDM
+	}
+
+	value.PlusDI, value.MinusDI = 0, 0
+	if s.tr != 0 {
+		value.PlusDI = s.plusDM / s.tr * 100
+		value.MinusDI = s.minusDM / s.tr * 100
+	}
+	dx := 0.0
+	if sum := value.PlusDI + value.MinusDI; sum != 0 {
+		dx = math.Abs(value.PlusDI-value.MinusDI) / sum * 100
+	}
+	value.ADX, ok = s.adx.update(dx)
+	return value, ok
+}
+
+// ADXSeries is the ADX of the series.
+type ADXSeries struct {
+	ADX     []float64
+	PlusDI  []float64
+	MinusDI []float64
+}
+
+// ADX is the average directional index of the bars.
+func ADX(bars []*klse.OHLC, period int) ADXSeries {
+	stream := NewADXStream(period)
+	results := ADXSeries{
+		ADX:     make([]float64, len(bars)),
+		PlusDI:  make([]float64, len(bars)),
+		MinusDI: make([]float64, len(bars)),
+	}
+	for i, bar := range bars {
+		value, _ := stream.Update(bar)
+		results.ADX[i], results.PlusDI[i], results.MinusDI[i] = value.ADX, value.PlusDI, value.MinusDI
+	}
+	return results
+}
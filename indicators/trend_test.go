@@ -0,0 +1,28 @@
+package indicators_test
+
+import (
+	"testing"
+
+	"github.com/kokweikhong/klsescreener-scraper/indicators"
+)
+
+func TestADX(t *testing.T) {
+	// the rising bars have +DM only, +DI is 100 x DM / TR and ADX is 100.
+	data := bars(
+		[]float64{10, 11, 12, 13, 14},
+		[]float64{9, 10, 11, 12, 13},
+		[]float64{9.5, 10.5, 11.5, 12.5, 13.5},
+		[]int{1, 1, 1, 1, 1},
+	)
+	adx := indicators.ADX(data, 2)
+	assertSeries(t, "ADX", adx.ADX, []float64{nan, nan, nan, 100, 100})
+	assertSeries(t, "+DI", adx.PlusDI, []float64{nan, nan, 100 / 1.5, 100 / 1.5, 100 / 1.5})
+	assertSeries(t, "-DI", adx.MinusDI, []float64{nan, nan, 0, 0, 0})
+
+	stream := indicators.NewADXStream(2)
+	for i, bar := range data {
+		if value, ok := stream.Update(bar); ok != (i >= 3) {
+			t.Errorf("ADX stream[%d] = %+v, %v", i, value, ok)
+		}
+	}
+}
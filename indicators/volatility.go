@@ -0,0 +1,112 @@
+package indicators
+
+import (
+	"math"
+
+	klse "github.com/kokweikhong/klsescreener-scraper"
+)
+
+// BollingerValue is the Bollinger Bands.
+type BollingerValue struct {
+	Middle float64 // simple moving average.
+	Upper  float64 // middle + k x standard deviation.
+	Lower  float64 // middle - k x standard deviation.
+}
+
+// BollingerStream is the streaming Bollinger Bands with the population
+// standard deviation of the period.
+type BollingerStream struct {
+	window window
+	k      float64
+}
+
+// NewBollingerStream is to initialise the Bollinger Bands of the period and
+// the number of standard deviations, eg 20, 2.
+func NewBollingerStream(period int, k float64) *BollingerStream {
+	mustPeriod("Bollinger", period)
+	return &BollingerStream{window: window{period: period}, k: k}
+}
+
+// Update is to add the close and get the bands, ok is false and the bands
+// are NaN before the period closes.
+func (s *BollingerStream) Update(close float64) (value BollingerValue, ok bool) {
+	s.window.push(close)
+	if !s.window.full() {
+		return BollingerValue{Middle: math.NaN(), Upper: math.NaN(), Lower: math.NaN()}, false
+	}
+	n := float64(s.window.period)
+	mean := 0.0
+	for _, v := range s.window.values {
+		mean += v
+	}
+	mean /= n
+	variance := 0.0
+	for _, v := range s.window.values {
+		variance += (v - mean) * (v - mean)
+	}
+	deviation := math.Sqrt(variance / n)
+	return BollingerValue{Middle: mean, Upper: mean + s.k*deviation, Lower: mean - s.k*deviation}, true
+}
+
+// BollingerSeries is the Bollinger Bands of the series.
+type BollingerSeries struct {
+	Middle []float64
+	Upper  []float64
+	Lower  []float64
+}
+
+// Bollinger is the Bollinger Bands of the closes.
+func Bollinger(closes []float64, period int, k float64) BollingerSeries {
+	stream := NewBollingerStream(period, k)
+	results := BollingerSeries{
+		Middle: make([]float64, len(closes)),
+		Upper:  make([]float64, len(closes)),
+		Lower:  make([]float64, len(closes)),
+	}
+	for i, close := range closes {
+		value, _ := stream.Update(close)
+		results.Middle[i], results.Upper[i], results.Lower[i] = value.Middle, value.Upper, value.Lower
+	}
+	return results
+}
+
+// trueRange is the true range of the bar with the previous close.
+func trueRange(bar *klse.OHLC, prevClose float64) float64 {
+	return math.Max(bar.High-bar.Low, math.Max(math.Abs(bar.High-prevClose), math.Abs(bar.Low-prevClose)))
+}
+
+// ATRStream is the streaming average true range with Wilder's smoothing,
+// the true range starts from the second bar which has the previous close.
+type ATRStream struct {
+	average   wilder
+	prevClose float64
+	started   bool
+}
+
+// NewATRStream is to initialise the average true range of the period, eg 14.
+func NewATRStream(period int) *ATRStream {
+	mustPeriod("ATR", period)
+	return &ATRStream{average: wilder{period: float64(period)}}
+}
+
+// Update is to add the bar and get the ATR, ok is false and the ATR is NaN
+// before the period true ranges, ie period + 1 bars.
+func (s *ATRStream) Update(bar *klse.OHLC) (atr float64, ok bool) {
+	prevClose := s.prevClose
+	s.prevClose = bar.Close
+	if !s.started {
+		s.started = true
+		return math.NaN(), false
+	}
+	return s.average.update(trueRange(bar, prevClose))
+}
+
+// ATR is the average true range of the bars.
+func ATR(bars []*klse.OHLC, period int) []float64 {
+	stream := NewATRStream(period)
+	results := make([]float64, len(bars))
+	for i, bar := range bars {
+		results[i], _ = stream.Update(bar)
+	}
+	return results
+}
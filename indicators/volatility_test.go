@@ -0,0 +1,37 @@
+package indicators_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kokweikhong/klsescreener-scraper/indicators"
+)
+
+func TestBollinger(t *testing.T) {
+	bands := indicators.Bollinger([]float64{2, 4, 4, 4, 5, 5, 7, 9}, 8, 2)
+	// mean 5, population standard deviation 2.
+	assertSeries(t, "Middle", bands.Middle[7:], []float64{5})
+	assertSeries(t, "Upper", bands.Upper[7:], []float64{9})
+	assertSeries(t, "Lower", bands.Lower[7:], []float64{1})
+	if !math.IsNaN(bands.Upper[6]) {
+		t.Errorf("Upper[6] = %v, want NaN", bands.Upper[6])
+	}
+}
+
+func TestATR(t *testing.T) {
+	data := bars(
+		[]float64{10, 12, 11, 15},
+		[]float64{8, 10, 9, 12},
+		[]float64{9, 11, 10, 14},
+		[]int{1, 1, 1, 1},
+	)
+	// true ranges 3, 2, 5 from the second bar.
+	assertSeries(t, "ATR", indicators.ATR(data, 2), []float64{nan, nan, 2.5, 3.75})
+
+	stream := indicators.NewATRStream(2)
+	for i, bar := range data {
+		if atr, ok := stream.Update(bar); ok != (i >= 2) {
+			t.Errorf("ATR stream[%d] = %v, %v", i, atr, ok)
+		}
+	}
+}
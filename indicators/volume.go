@@ -0,0 +1,78 @@
+package indicators
+
+import (
+	"math"
+
+	klse "github.com/kokweikhong/klsescreener-scraper"
+)
+
+// OBVStream is the streaming on-balance volume, it is 0 at the first bar.
+type OBVStream struct {
+	obv       float64
+	prevClose float64
+	started   bool
+}
+
+// NewOBVStream is to initialise the on-balance volume.
+func NewOBVStream() *OBVStream {
+	return &OBVStream{}
+}
+
+// Update is to add the bar and get the OBV, the volume is added when the
+// close is up and subtracted when the close is down.
+func (s *OBVStream) Update(bar *klse.OHLC) float64 {
+	switch {
+	case !s.started:
+		s.started = true
+	case bar.Close > s.prevClose:
+		s.obv += float64(bar.Volume)
+	case bar.Close < s.prevClose:
+		s.obv -= float64(bar.Volume)
+	}
+	s.prevClose = bar.Close
+	return s.obv
+}
+
+// OBV is the on-balance volume of the bars.
+func OBV(bars []*klse.OHLC) []float64 {
+	stream := NewOBVStream()
+	results := make([]float64, len(bars))
+	for i, bar := range bars {
+		results[i] = stream.Update(bar)
+	}
+	return results
+}
+
+// VWAPStream is the streaming volume weighted average price anchored at the
+// first bar, the price of the bar is the typical price (high + low + close) / 3.
+type VWAPStream struct {
+	value  float64
+	volume float64
+}
+
+// NewVWAPStream is to initialise the volume weighted average price.
+func NewVWAPStream() *VWAPStream {
+	return &VWAPStream{}
+}
+
+// Update is to add the bar and get the VWAP, ok is false and the VWAP is NaN
+// before any volume.
+func (s *VWAPStream) Update(bar *klse.OHLC) (vwap float64, ok bool) {
+	volume := float64(bar.Volume)
+	s.value += (bar.High + bar.Low + bar.Close) / 3 * volume
+	s.volume += volume
+	if s.volume == 0 {
+		return math.NaN(), false
+	}
+	return s.value / s.volume, true
+}
+
+// VWAP is the volume weighted average price of the bars from the first bar.
+func VWAP(bars []*klse.OHLC) []float64 {
+	stream := NewVWAPStream()
+	results := make([]float64, len(bars))
+	for i, bar := range bars {
+		results[i], _ = stream.Update(bar)
+	}
+	return results
+}
@@ -0,0 +1,28 @@
+package indicators_test
+
+import (
+	"testing"
+
+	"github.com/kokweikhong/klsescreener-scraper/indicators"
+)
+
+func TestOBV(t *testing.T) {
+	data := bars(
+		[]float64{1, 1, 1, 1},
+		[]float64{1, 1, 1, 1},
+		[]float64{1, 2, 2, 1},
+		[]int{100, 200, 300, 400},
+	)
+	assertSeries(t, "OBV", indicators.OBV(data), []float64{0, 200, 200, -200})
+}
+
+func TestVWAP(t *testing.T) {
+	data := bars(
+		[]float64{3, 6, 9},
+		[]float64{1, 3, 3},
+		[]float64{2, 3, 6},
+		[]int{0, 100, 300},
+	)
+	// typical prices 2, 4, 6.
+	assertSeries(t, "VWAP", indicators.VWAP(data), []float64{nan, 4, 5.5})
+}
@@ -0,0 +1,67 @@
+// Package fixture is the test helper of every package to replay the saved
+// klsescreener responses without network, or record them again from
+// klsescreener.com with go test ./... -record.
+package fixture
+
+import (
+	"bufio"
+	"flag"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	klse "github.com/kokweikhong/klsescreener-scraper"
+)
+
+// record is to update the fixtures from klsescreener.com, go test ./... -record
+var record = flag.Bool("record", false, "record the fixtures from klsescreener.com")
+
+// Recording is to check the tests are run with -record.
+func Recording() bool {
+	return *record
+}
+
+// NewClient is the client serves the responses from the fixtures of the
+// directory without network, or records the fixtures with -record flag.
+func NewClient(dir string, options ...klse.ClientOption) *klse.Client {
+	if *record {
+		transport := klse.NewRecordingTransport(dir, nil)
+		options = append([]klse.ClientOption{
+			klse.WithHTTPClient(&http.Client{Transport: transport}),
+		}, options...)
+		return klse.NewClient(options...)
+	}
+	options = append([]klse.ClientOption{
+		klse.WithHTTPClient(&http.Client{Transport: klse.NewReplayTransport(dir)}),
+		klse.WithRateLimit(0, 0),
+		klse.WithChartRateLimit(0, 0),
+		klse.WithRetryPolicy(klse.RetryPolicy{}),
+	}, options...)
+	return klse.NewClient(options...)
+}
+
+// Handwritten is to list the fixtures of the directory which are not saved
+// by klse.NewRecordingTransport, ie without the Date header which the server
+// sends and httputil.DumpResponse keeps.
+func Handwritten(dir string) ([]string, error) {
+	names, err := filepath.Glob(filepath.Join(dir, "*.http"))
+	if err != nil {
+		return nil, err
+	}
+	handwritten := []string{}
+	for _, name := range names {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := http.ReadResponse(bufio.NewReader(f), nil)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		if resp.Header.Get("Date") == "" {
+			handwritten = append(handwritten, filepath.Base(name))
+		}
+	}
+	return handwritten, nil
+}
@@ -0,0 +1,213 @@
+package klse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// intradayPathSuffix is the suffix of the chart path of the minute bars of
+// the latest trading day. The path follows the periods of the daily chart,
+// eg "/embedded/1y", it is not verified with a recorded page of
+// klsescreener yet and there is no fixture of it in testdata/fixtures.
+const intradayPathSuffix = "/embedded/1d"
+
+// IntradayInterval is the length of the intraday bar.
+type IntradayInterval time.Duration
+
+const (
+	Intraday1Minute IntradayInterval = IntradayInterval(time.Minute)
+	Intraday5Minute IntradayInterval = IntradayInterval(5 * time.Minute)
+)
+
+// String is the interval, eg "5m0s".
+func (i IntradayInterval) String() string {
+	return time.Duration(i).String()
+}
+
+// GetStockIntraday is to get the intraday bars of the latest trading day
+// with the default client.
+func GetStockIntraday(code string, interval IntradayInterval) ([]*OHLC, error) {
+	return DefaultClient.GetStockIntraday(code, interval)
+}
+
+// GetStockIntradayContext is GetStockIntraday with context using the default client.
+func GetStockIntradayContext(ctx context.Context, code string, interval IntradayInterval) ([]*OHLC, error) {
+	return DefaultClient.GetStockIntradayContext(ctx, code, interval)
+}
+
+// GetStockIntraday is to get the intraday bars of the latest trading day,
+// Date is the start time of the bar in MYT, eg 2022-07-08 09:05 for the
+// 5-minute bar from 09:05 to 09:10. The minute bars are from the 1 day chart
+// of the stock and the longer interval are aggregated from them, the last
+// bar is still forming during the trading session.
+// The 1 day chart endpoint is unverified, it is ErrNoChartData or
+// *StatusError when klsescreener doesn't serve the minute bars there, and
+// ErrNotIntraday when the bars are not the minute bars of one trading day,
+// eg the daily chart.
+func (c *Client) GetStockIntraday(code string, interval IntradayInterval) ([]*OHLC, error) {
+	return c.GetStockIntradayContext(context.Background(), code, interval)
+}
+
+// GetStockIntradayContext is GetStockIntraday with context.
+func (c *Client) GetStockIntradayContext(ctx context.Context, code string, interval IntradayInterval) ([]*OHLC, error) {
+	if interval < Intraday1Minute || time.Duration(interval)%time.Minute != 0 {
+		return nil, fmt.Errorf("klse: intraday interval %v is not whole minutes", interval)
+	}
+	path := fmt.Sprintf("/v2/stocks/chart/%s%s", code, intradayPathSuffix)
+	p := c.newParser(ctx, c.baseURL+path, Field{Key: "code", Value: code})
+	resp, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	bars, err := readChart(p, resp.Body, 6, p.parseChartIntraday)
+	if bars == nil {
+		return nil, err
+	}
+	if err := checkIntraday(bars); err != nil {
+		return nil, fmt.Errorf("%w: %s : %s", ErrNotIntraday, p.url, err)
+	}
+	return resampleIntraday(bars, interval), err
+}
+
+// checkIntraday is to check the bars are the minute bars of one trading day
+// in MYT sorted by time, so the daily chart served for the unverified path
+// is not resampled as minute bars.
+func checkIntraday(bars []*OHLC) error {
+	day := NewTradingDate(bars[0].Date)
+	for i, bar := range bars {
+		switch {
+		case NewTradingDate(bar.Date) != day:
+			return fmt.Errorf("bar %s is not on %s", bar.Date.Format(time.RFC3339), day)
+		case !bar.Date.Truncate(time.Minute).Equal(bar.Date):
+			return fmt.Errorf("bar %s is not at a whole minute", bar.Date.Format(time.RFC3339))
+		case i > 0 && !bar.Date.After(bars[i-1].Date):
+			return fmt.Errorf("bar %s is not after %s", bar.Date.Format(time.RFC3339), bars[i-1].Date.Format(time.RFC3339))
+		}
+	}
+	return nil
+}
+
+// parseChartIntraday is to parse the row of the minute bar with the full timestamp.
+func (p *parser) parseChartIntraday(field string, values []string) *OHLC {
+	return &OHLC{
+		Date:   p.chartTime(field+"date", values[0]),
+		Open:   p.float(field+"open", values[1], 3),
+		High:   p.float(field+"high", values[2], 3),
+		Low:    p.float(field+"low", values[3], 4),
+		Close:  p.float(field+"close", values[4], 5),
+		Volume: p.integer(field+"volume", values[5]),
+		Exact:  p.exactOHLC(field, values[1], values[2], values[3], values[4]),
+	}
+}
+
+// chartTime is to convert the chart timestamp in milliseconds of the field
+// to time.Time in MYT.
+func (p *parser) chartTime(field, text string) time.Time {
+	msec, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		p.issue(field, text, err)
+		return time.Time{}
+	}
+	return time.UnixMilli(msec).In(MYT)
+}
+
+// resampleIntraday is to aggregate the minute bars sorted by time into the
+// bars of the interval which start at the multiple of the interval in MYT.
+func resampleIntraday(bars []*OHLC, interval IntradayInterval) []*OHLC {
+	if interval == Intraday1Minute {
+		return bars
+	}
+	resampled := []*OHLC{}
+	for _, bar := range bars {
+		start := truncateInMYT(bar.Date, time.Duration(interval))
+		last := len(resampled) - 1
+		if last < 0 || !resampled[last].Date.Equal(start) {
+			next := *bar
+			next.Date = start
+			resampled = append(resampled, &next)
+			continue
+		}
+		merged := resampled[last]
+		if bar.High > merged.High {
+			merged.High = bar.High
+		}
+		if bar.Low < merged.Low {
+			merged.Low = bar.Low
+		}
+		merged.Close = bar.Close
+		merged.Volume += bar.Volume
+		merged.Exact = mergeExactOHLC(merged.Exact, bar.Exact)
+	}
+	return resampled
+}
+
+// mergeExactOHLC is to aggregate the exact prices of the next bar, nil
+// when any of them has no exact prices.
+func mergeExactOHLC(exact, next *ExactOHLC) *ExactOHLC {
+	if exact == nil || next == nil {
+		return nil
+	}
+	merged := *exact
+	if next.High.Cmp(merged.High) > 0 {
+		merged.High = next.High
+	}
+	if next.Low.Cmp(merged.Low) < 0 {
+		merged.Low = next.Low
+	}
+	merged.Close = next.Close
+	return &merged
+}
+
+// truncateInMYT is to round the time down to the multiple of d since the
+// midnight MYT, eg 09:07 is 09:05 for 5 minutes.
+func truncateInMYT(t time.Time, d time.Duration) time.Time {
+	t = t.In(MYT)
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, MYT)
+	return midnight.Add(t.Sub(midnight) / d * d)
+}
+
+// IntradayPoller is to poll the intraday bars of the stock and get only the
+// new bars since the last poll.
+type IntradayPoller struct {
+	client   *Client
+	code     string
+	interval IntradayInterval
+	last     time.Time // start time of the latest bar returned.
+}
+
+// NewIntradayPoller is to initialise the poller of the intraday bars of
+// the stock with the default client.
+func NewIntradayPoller(code string, interval IntradayInterval) *IntradayPoller {
+	return DefaultClient.NewIntradayPoller(code, interval)
+}
+
+// NewIntradayPoller is to initialise the poller of the intraday bars of the stock.
+func (c *Client) NewIntradayPoller(code string, interval IntradayInterval) *IntradayPoller {
+	return &IntradayPoller{client: c, code: code, interval: interval}
+}
+
+// Poll is to get the completed bars after the bars of the last poll, the bar
+// which is still forming by the clock of the client is returned by the
+// next poll after it is completed.
+func (p *IntradayPoller) Poll(ctx context.Context) ([]*OHLC, error) {
+	bars, err := p.client.GetStockIntradayContext(ctx, p.code, p.interval)
+	if bars == nil {
+		return nil, err
+	}
+	now := p.client.clock()
+	fresh := []*OHLC{}
+	for _, bar := range bars {
+		completed := !bar.Date.Add(time.Duration(p.interval)).After(now)
+		if bar.Date.After(p.last) && completed {
+			fresh = append(fresh, bar)
+		}
+	}
+	if len(fresh) > 0 {
+		p.last = fresh[len(fresh)-1].Date
+	}
+	return fresh, err
+}
@@ -0,0 +1,163 @@
+package klse_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	klse "github.com/kokweikhong/klsescreener-scraper"
+)
+
+// intradayRows is the minute bars from 09:00 to 09:06 MYT on 2022-07-08.
+const intradayRows = `[1657242000000,1.000,1.020,0.995,1.010,1000],
+	[1657242060000,1.010,1.030,1.005,1.025,2000],
+	[1657242120000,1.025,1.025,0.990,0.995,1500],
+	[1657242180000,0.995,1.000,0.985,0.990,500],
+	[1657242240000,0.990,1.005,0.990,1.000,800],
+	[1657242300000,1.000,1.040,1.000,1.035,3000],
+	[1657242360000,1.035,1.035,1.020,1.030,1200],`
+
+// intradayClock is the clock at the minutes after 09:00 MYT on 2022-07-08,
+// the minutes can be changed by the test.
+func intradayClock(minutes *float64) func() time.Time {
+	return func() time.Time {
+		open := time.Date(2022, time.July, 8, 9, 0, 0, 0, klse.MYT)
+		return open.Add(time.Duration(*minutes * float64(time.Minute)))
+	}
+}
+
+func TestGetStockIntraday(t *testing.T) {
+	server := chartPageServer(intradayRows)
+	defer server.Close()
+	client := klse.NewClient(klse.WithBaseURL(server.URL), klse.WithExactDecimals())
+
+	bars, err := client.GetStockIntraday("5099", klse.Intraday1Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bars) != 7 {
+		t.Fatalf("1 minute bars = %d, want 7", len(bars))
+	}
+	if want := time.Date(2022, time.July, 8, 9, 1, 0, 0, klse.MYT); !bars[1].Date.Equal(want) ||
+		bars[1].Date.Location() != klse.MYT || bars[1].Close != 1.025 {
+		t.Errorf("bars[1] = %v %v", bars[1].Date, bars[1].Close)
+	}
+
+	bars, err = client.GetStockIntraday("5099", klse.Intraday5Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bars) != 2 {
+		t.Fatalf("5 minute bars = %d, want 2", len(bars))
+	}
+	first := bars[0]
+	if want := time.Date(2022, time.July, 8, 9, 0, 0, 0, klse.MYT); !first.Date.Equal(want) ||
+		first.Open != 1 || first.High != 1.03 || first.Low != 0.985 || first.Close != 1 || first.Volume != 5800 {
+		t.Errorf("bars[0] = %+v", first)
+	}
+	if first.Exact == nil || first.Exact.High.String() != "1.03" || first.Exact.Close.String() != "1" {
+		t.Errorf("bars[0].Exact = %+v", first.Exact)
+	}
+	if want := time.Date(2022, time.July, 8, 9, 5, 0, 0, klse.MYT); !bars[1].Date.Equal(want) || bars[1].Volume != 4200 {
+		t.Errorf("bars[1] = %+v", bars[1])
+	}
+
+	if _, err := client.GetStockIntraday("5099", klse.IntradayInterval(90*time.Second)); err == nil {
+		t.Error("90s interval err = nil")
+	}
+}
+
+func TestGetStockIntradaySkippedRows(t *testing.T) {
+	server := chartPageServer(`[1657242000000,1.000,1.020,0.995,1.010,1000],
+		["09:01",1.010,1.030,1.005,1.025,2000],`)
+	defer server.Close()
+	client := klse.NewClient(klse.WithBaseURL(server.URL))
+
+	bars, err := client.GetStockIntraday("5099", klse.Intraday1Minute)
+	var skipped *klse.SkippedRowsError
+	if !errors.As(err, &skipped) || len(skipped.Rows) != 1 || skipped.Rows.Valid("data[1].date") {
+		t.Fatalf("err = %v", err)
+	}
+	if len(bars) != 1 {
+		t.Errorf("bars = %d, want 1", len(bars))
+	}
+}
+
+func TestGetStockIntradayDailyBars(t *testing.T) {
+	tests := []struct {
+		name, rows string
+	}{
+		{"daily", `[1657209600000,1.000,1.020,0.995,1.010,1000],[1657296000000,1.010,1.030,1.005,1.025,2000],`},
+		{"seconds", `[1657242000000,1.000,1.020,0.995,1.010,1000],[1657242030000,1.010,1.030,1.005,1.025,2000],`},
+		{"unsorted", `[1657242060000,1.000,1.020,0.995,1.010,1000],[1657242000000,1.010,1.030,1.005,1.025,2000],`},
+	}
+	for _, tt := range tests {
+		server := chartPageServer(tt.rows)
+		client := klse.NewClient(klse.WithBaseURL(server.URL))
+		bars, err := client.GetStockIntraday("5099", klse.Intraday5Minute)
+		if !errors.Is(err, klse.ErrNotIntraday) || bars != nil {
+			t.Errorf("%s: bars = %d err = %v, want ErrNotIntraday", tt.name, len(bars), err)
+		}
+		server.Close()
+	}
+}
+
+func TestIntradayPoller(t *testing.T) {
+	server := chartPageServer(intradayRows)
+	defer server.Close()
+	minutes := 4.5
+	client := klse.NewClient(klse.WithBaseURL(server.URL), klse.WithClock(intradayClock(&minutes)))
+
+	poller := client.NewIntradayPoller("5099", klse.Intraday1Minute)
+	for _, tt := range []struct {
+		minutes float64
+		want    int
+	}{
+		{4.5, 4}, // 09:04 is still forming.
+		{4.5, 0},
+		{7, 3},
+		{8, 0},
+	} {
+		minutes = tt.minutes
+		bars, err := poller.Poll(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(bars) != tt.want {
+			t.Errorf("at %v minutes: bars = %d, want %d", tt.minutes, len(bars), tt.want)
+		}
+	}
+
+	minutes = 7
+	poller = client.NewIntradayPoller("5099", klse.Intraday5Minute)
+	bars, _ := poller.Poll(context.Background())
+	if len(bars) != 1 || bars[0].Volume != 5800 {
+		t.Errorf("5 minute bars at 09:07 = %d", len(bars))
+	}
+	minutes = 10
+	bars, _ = poller.Poll(context.Background())
+	if len(bars) != 1 || bars[0].Date.Minute() != 5 {
+		t.Errorf("5 minute bars at 09:10 = %d", len(bars))
+	}
+}
+
+func TestCacheIntraday(t *testing.T) {
+	server := newTestServer(cachePage)
+	defer server.Close()
+
+	// the intraday chart is not cached with the ttl of the daily chart.
+	client := klse.NewClient(
+		klse.WithBaseURL(server.URL),
+		klse.WithCache(klse.NewMemoryCache(10), map[klse.Endpoint]time.Duration{klse.EndpointChart: time.Hour}),
+	)
+	client.GetStockIntraday("0001", klse.Intraday1Minute)
+	client.GetStockIntraday("0001", klse.Intraday1Minute)
+	if server.Requests() != 2 {
+		t.Errorf("requests = %d, want 2", server.Requests())
+	}
+
+	if klse.DefaultCacheTTL()[klse.EndpointIntraday] >= time.Minute {
+		t.Errorf("intraday ttl = %v, want less than a minute", klse.DefaultCacheTTL()[klse.EndpointIntraday])
+	}
+}
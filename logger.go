@@ -0,0 +1,91 @@
+package klse
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"strings"
+)
+
+// LogLevel is the severity of the log.
+type LogLevel int
+
+const (
+	LogDebug LogLevel = iota
+	LogInfo
+	LogWarning
+	LogError
+)
+
+// String is to get the name of log level.
+func (l LogLevel) String() string {
+	switch l {
+	case LogDebug:
+		return "DEBUG"
+	case LogInfo:
+		return "INFO"
+	case LogWarning:
+		return "WARNING"
+	case LogError:
+		return "ERROR"
+	}
+	return fmt.Sprintf("LEVEL(%d)", int(l))
+}
+
+// Field is the structured key value of the log, eg url, code, row.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// Logger is the interface to write the client's logs.
+// It can be adapted to any structured logger, eg log/slog or zap.
+type Logger interface {
+	Log(level LogLevel, msg string, fields ...Field)
+}
+
+// WithLogger is the option to set the client's logger,
+// the client is silent by default.
+func WithLogger(logger Logger) ClientOption {
+	return func(c *Client) {
+		if logger == nil {
+			logger = nopLogger{}
+		}
+		c.logger = logger
+	}
+}
+
+// nopLogger is the default logger which discard all the logs.
+type nopLogger struct{}
+
+// Log is to implement Logger interface.
+func (nopLogger) Log(LogLevel, string, ...Field) {}
+
+// stdLogger is the Logger writes with standard library log.
+type stdLogger struct {
+	logger *log.Logger
+	level  LogLevel
+}
+
+// NewLogger is to initialise Logger writes to w with the level and above,
+// eg NewLogger(os.Stderr, LogInfo).
+// Output : 2022/07/01 12:00:00 [WARNING] invalid date url=https://... row=3
+func NewLogger(w io.Writer, level LogLevel) Logger {
+	return &stdLogger{
+		logger: log.New(w, "", log.LstdFlags),
+		level:  level,
+	}
+}
+
+// Log is to implement Logger interface.
+func (l *stdLogger) Log(level LogLevel, msg string, fields ...Field) {
+	if level < l.level {
+		return
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s] %s", level, msg)
+	for _, field := range fields {
+		fmt.Fprintf(&b, " %s=%v", field.Key, field.Value)
+	}
+	l.logger.Print(b.String())
+}
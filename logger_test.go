@@ -0,0 +1,74 @@
+package klse_test
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	klse "github.com/kokweikhong/klsescreener-scraper"
+)
+
+// logEntry is the log written to testLogger.
+type logEntry struct {
+	level  klse.LogLevel
+	msg    string
+	fields map[string]interface{}
+}
+
+// testLogger is the Logger to keep all the logs.
+type testLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+func (l *testLogger) Log(level klse.LogLevel, msg string, fields ...klse.Field) {
+	entry := logEntry{level: level, msg: msg, fields: map[string]interface{}{}}
+	for _, field := range fields {
+		entry.fields[field.Key] = field.Value
+	}
+	l.mu.Lock()
+	l.entries = append(l.entries, entry)
+	l.mu.Unlock()
+}
+
+func TestWithLogger(t *testing.T) {
+	server := chartPageServer(`[1656288000000,1,2,0.5,1.5,100],[1656374400000,1.5,2,1,1.8,200],`)
+	defer server.Close()
+
+	logger := &testLogger{}
+	client := klse.NewClient(klse.WithBaseURL(server.URL), klse.WithLogger(logger))
+	if _, err := client.GetStockHistoricalData("0001"); err != nil {
+		t.Fatal(err)
+	}
+
+	var rows []interface{}
+	for _, entry := range logger.entries {
+		if entry.msg != "getting historical data" {
+			continue
+		}
+		if entry.level != klse.LogDebug {
+			t.Errorf("level = %v, want %v", entry.level, klse.LogDebug)
+		}
+		if entry.fields["code"] != "0001" || !strings.HasPrefix(entry.fields["url"].(string), server.URL) {
+			t.Errorf("fields = %v, want code and url", entry.fields)
+		}
+		rows = append(rows, entry.fields["row"])
+	}
+	if len(rows) != 2 || rows[0] != 0 || rows[1] != 1 {
+		t.Errorf("rows = %v, want [0 1]", rows)
+	}
+}
+
+func TestNewLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := klse.NewLogger(&buf, klse.LogWarning)
+	logger.Log(klse.LogInfo, "hidden")
+	logger.Log(klse.LogWarning, "invalid date", klse.Field{Key: "row", Value: 3})
+	if strings.Contains(buf.String(), "hidden") {
+		t.Errorf("log below level is written : %q", buf.String())
+	}
+	if !strings.Contains(buf.String(), "[WARNING] invalid date row=3") {
+		t.Errorf("log = %q, want warning with row field", buf.String())
+	}
+}
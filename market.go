@@ -1,6 +1,7 @@
 package klse
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
@@ -18,6 +19,7 @@ type MarketInformation struct {
 	TopLosers           []*marketDetails `json:"top_losers"`
 	TopLosersByPercent  []*marketDetails `json:"top_losers_by_percentage"`
 	BursaIndex          []*marketDetails `json:"bursa_index"`
+	Report              ParseReport      `json:"parse_report,omitempty"`
 }
 
 // marketDetails is the every market information data's data structure.
@@ -31,19 +33,48 @@ type marketDetails struct {
 	ChangesPercent float64 `json:"changes_percent,omitempty"`
 }
 
+// GetMarketInformation is to get all information from market page
+// with the default client.
+func GetMarketInformation() (*MarketInformation, error) {
+	return DefaultClient.GetMarketInformation()
+}
+
+// GetMarketInformationContext is GetMarketInformation with context
+// using the default client.
+func GetMarketInformationContext(ctx context.Context) (*MarketInformation, error) {
+	return DefaultClient.GetMarketInformationContext(ctx)
+}
+
 // GetMarketInformation is to get all information from market page.
 // Market Index, Top Active, Top Turnover, Top Gainers, Top Losers, Bursa Index.
-func GetMarketInformation() *MarketInformation {
-	market := &MarketInformation{}
-	url := "https://www.klsescreener.com/v2/markets"
-	resp := newRequest(http.MethodGet, url, nil)
-	defer resp.Body.Close()
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+func (c *Client) GetMarketInformation() (*MarketInformation, error) {
+	return c.GetMarketInformationContext(context.Background())
+}
+
+// GetMarketInformationContext is GetMarketInformation with context.
+func (c *Client) GetMarketInformationContext(ctx context.Context) (*MarketInformation, error) {
+	url := c.baseURL + "/v2/markets"
+	p := c.newParser(ctx, url)
+	resp, err := c.newRequest(ctx, http.MethodGet, "/v2/markets", nil)
 	if err != nil {
-		logError.Fatalf("%s, %s : %s", url, resp.Status, err.Error())
+		return nil, err
 	}
+	defer resp.Body.Close()
+	return parseDocument(p, resp.Body, p.parseMarketInformation)
+}
+
+// parseMarketInformation is to parse the market page.
+func (p *parser) parseMarketInformation(doc *goquery.Document) *MarketInformation {
+	market := &MarketInformation{}
+	sections := []string{"market_index", "top_active", "top_turnover", "top_gainers",
+		"top_gainers_by_percentage", "top_losers", "top_losers_by_percentage", "bursa_index"}
 	doc.Find(`#content div.row.equal`).Each(func(marketIndex int, s *goquery.Selection) {
-		s.Find(`div.col-md-4`).Each(func(_ int, s *goquery.Selection) {
+		section := fmt.Sprintf("section_%d", marketIndex)
+		if marketIndex < len(sections) {
+			section = sections[marketIndex]
+		}
+		s.Find(`div.col-md-4`).Each(func(i int, s *goquery.Selection) {
+			field := elementField(section, i)
 			var name, link, country string
 			var price, changes, changesPercent float64
 			var volume int
@@ -51,7 +82,7 @@ func GetMarketInformation() *MarketInformation {
 			name = removeAllSpaces(aLink.First().Text(), " ")
 			link, _ = aLink.Attr("href")
 			lastPrice := s.Find(`span.last`).First().Text()
-			price = convertStringToFloat64(removeAllSpaces(lastPrice, ""), 2)
+			price = p.float(field+"price", removeAllSpaces(lastPrice, ""), 2)
 			if marketIndex == 0 {
 				country = removeAllSpaces(s.Find(`.col-sm-7 > div:nth-child(2)`).Text(), " ")
 			}
@@ -63,13 +94,15 @@ func GetMarketInformation() *MarketInformation {
 				stringChanges = s.Find(`span[data-value="price_change"]`).Text()
 				changesSplit := strings.Split(strings.TrimSpace(stringChanges), " ")
 				if len(changesSplit) > 1 {
-					changes = convertStringToFloat64(changesSplit[0], 6)
-					changesPercent = convertStringToFloat64(strings.ReplaceAll(changesSplit[1], "%", ""), 2) / 100
+					changes = p.float(field+"changes", changesSplit[0], 6)
+					changesPercent = p.float(field+"changes_percent", strings.ReplaceAll(changesSplit[1], "%", ""), 2) / 100
+				} else {
+					p.issue(field+"changes", stringChanges, ErrNoValue)
 				}
 			} else if marketIndex == 7 {
 				stringChanges = s.Find(`div[data-value="price_change"]`).Text()
 				stringChanges = strings.ReplaceAll(stringChanges, "%", "")
-				changesPercent = convertStringToFloat64(removeAllSpaces(stringChanges, ""), 6) / 100
+				changesPercent = p.float(field+"changes_percent", removeAllSpaces(stringChanges, ""), 6) / 100
 			}
 			if changesPercent != 0 {
 				changesPercent = convertStringToFloat64(fmt.Sprintf("%.6f", changesPercent), 6)
@@ -77,10 +110,10 @@ func GetMarketInformation() *MarketInformation {
 			switch marketIndex {
 			case 1:
 				vol := s.Find(`div.volume`).Text()
-				volume = int(convertStringToFloat64(removeAllSpaces(vol, ""), 0))
+				volume = p.integer(field+"volume", removeAllSpaces(vol, ""))
 			case 2:
 				vol := s.Find(`.col-sm-5 > div[data-type="val"]`).Text()
-				volume = int(convertStringToFloat64(removeAllSpaces(vol, ""), 0))
+				volume = p.integer(field+"volume", removeAllSpaces(vol, ""))
 			}
 			detail := &marketDetails{
 				Name:           name,
@@ -111,5 +144,6 @@ func GetMarketInformation() *MarketInformation {
 			}
 		})
 	})
+	market.Report = p.takeReport()
 	return market
 }
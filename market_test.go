@@ -1,24 +1,49 @@
 package klse_test
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
-
-	klse "github.com/kokweikhong/klsescreener-scraper"
 )
 
 func TestGetMarketInformation(t *testing.T) {
-	market := klse.GetMarketInformation()
-	b, _ := json.MarshalIndent(market, "", "  ")
-	fmt.Println(string(b))
+	market, err := newFixtureClient(t).GetMarketInformation()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(market.MarketIndex) != 2 || len(market.BursaIndex) != 2 {
+		t.Fatalf("market index = %d, bursa index = %d, want 2 and 2", len(market.MarketIndex), len(market.BursaIndex))
+	}
+	klci := market.MarketIndex[0]
+	if klci.Name != "FTSE Bursa Malaysia KLCI" || klci.Country != "Malaysia" ||
+		klci.Price != 1445.35 || klci.Changes != 2.5 || klci.ChangesPercent != 0.0017 {
+		t.Errorf("market index = %+v", klci)
+	}
+	if active := market.TopActive[0]; active.Name != "KRONO" || active.Volume != 52145300 {
+		t.Errorf("top active = %+v", active)
+	}
+	if turnover := market.TopTurnover[0]; turnover.Volume != 98765432 {
+		t.Errorf("top turnover = %+v", turnover)
+	}
+	if loser := market.TopLosersByPercent[0]; loser.Changes != -0.035 || loser.ChangesPercent != -0.14 {
+		t.Errorf("top losers by percent = %+v", loser)
+	}
+	if property := market.BursaIndex[0]; property.Price != 640.12 || property.ChangesPercent != 0.0052 ||
+		property.Link != "https://www.klsescreener.com/v2/stocks/view/0020I" {
+		t.Errorf("bursa index = %+v", property)
+	}
+
+	// the keys of bursa index can be generated from the links.
+	var keys []string
 	for _, v := range market.BursaIndex {
 		idx := strings.Split(v.Link, "/")
 		name := strings.ReplaceAll(v.Name, "&", "AND")
-        name = strings.ReplaceAll(name, "/", "_")
+		name = strings.ReplaceAll(name, "/", "_")
 		name = strings.ReplaceAll(name, " ", "_")
 		name = strings.ToUpper(name)
-		fmt.Printf("%s BURSA_INDEX = \"%s\"\n", name, idx[len(idx)-1])
+		keys = append(keys, fmt.Sprintf("%s BURSA_INDEX = \"%s\"", name, idx[len(idx)-1]))
+	}
+	if keys[1] != `FINANCIAL_SERVICES BURSA_INDEX = "0010I"` {
+		t.Errorf("keys = %v", keys)
 	}
 }
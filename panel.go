@@ -0,0 +1,224 @@
+package klse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+)
+
+// defaultPanelWorkers is the number of the codes loaded at once by default,
+// the requests are still limited by the rate limit of the client.
+const defaultPanelWorkers = 4
+
+// FillPolicy is the policy of the missing values of the panel, eg the code
+// is suspended on the trading date of the other codes.
+type FillPolicy int
+
+const (
+	// FillNaN is to leave the missing close, volume and return as NaN.
+	FillNaN FillPolicy = iota
+	// FillForward is to fill the missing close with the last close, the
+	// volume is 0 and the return is 0. The dates before the first bar of
+	// the code are still NaN.
+	FillForward
+)
+
+// PanelOption is the option of LoadPanel.
+type PanelOption func(*panelConfig)
+
+// panelConfig is the configuration of LoadPanel.
+type panelConfig struct {
+	workers int
+	fill    FillPolicy
+}
+
+// WithWorkers is the option to load n codes at once, it is 4 by default.
+func WithWorkers(n int) PanelOption {
+	return func(c *panelConfig) {
+		if n < 1 {
+			n = 1
+		}
+		c.workers = n
+	}
+}
+
+// WithFill is the option of the policy of the missing values, it is FillNaN by default.
+func WithFill(policy FillPolicy) PanelOption {
+	return func(c *panelConfig) {
+		c.fill = policy
+	}
+}
+
+// Panel is the daily data of the codes aligned by the trading dates, the
+// matrix is indexed by [date][code], eg Close[i][j] is the close of
+// Codes[j] on Dates[i].
+type Panel struct {
+	Dates  []TradingDate // union of the trading dates of the codes, sorted.
+	Codes  []string      // codes with bars in the order requested without duplicates.
+	Close  [][]float64
+	Volume [][]float64
+	// Return is the simple return of the close from the previous date of
+	// the panel, NaN on the first date and when either close is NaN.
+	Return [][]float64
+	// Errors is the error of every code which can't be loaded, the code
+	// with skipped rows or missing values is in both Codes and Errors with
+	// *SkippedRowsError, the close of the skipped rows is NaN.
+	Errors map[string]error
+}
+
+// Column is the index of the code in Codes, -1 when not found.
+func (p *Panel) Column(code string) int {
+	for j, c := range p.Codes {
+		if c == code {
+			return j
+		}
+	}
+	return -1
+}
+
+// Row is the index of the date in Dates, -1 when not found.
+func (p *Panel) Row(date TradingDate) int {
+	i := sort.Search(len(p.Dates), func(i int) bool {
+		return !p.Dates[i].Before(date)
+	})
+	if i < len(p.Dates) && p.Dates[i] == date {
+		return i
+	}
+	return -1
+}
+
+// LoadPanel is to load the historical data of the period of the codes with
+// the default client.
+func LoadPanel(codes []string, period Period, opts ...PanelOption) (*Panel, error) {
+	return DefaultClient.LoadPanel(codes, period, opts...)
+}
+
+// LoadPanelContext is LoadPanel with context using the default client.
+func LoadPanelContext(ctx context.Context, codes []string, period Period, opts ...PanelOption) (*Panel, error) {
+	return DefaultClient.LoadPanelContext(ctx, codes, period, opts...)
+}
+
+// LoadPanel is to load the historical data of the period of the codes
+// concurrently and align them on the union of the trading dates.
+// The error of a code doesn't stop the others, it is in Panel.Errors.
+func (c *Client) LoadPanel(codes []string, period Period, opts ...PanelOption) (*Panel, error) {
+	return c.LoadPanelContext(context.Background(), codes, period, opts...)
+}
+
+// LoadPanelContext is LoadPanel with context. The error is the context error
+// when the context is cancelled, the panel of the codes loaded is still
+// returned. The period which is not one of the preset periods is ErrInvalidPeriod.
+func (c *Client) LoadPanelContext(ctx context.Context, codes []string, period Period, opts ...PanelOption) (*Panel, error) {
+	config := &panelConfig{workers: defaultPanelWorkers, fill: FillNaN}
+	for _, opt := range opts {
+		opt(config)
+	}
+	if !period.Valid() {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidPeriod, period)
+	}
+	codes = uniqueCodes(codes)
+
+	results := make([][]*OHLC, len(codes))
+	errs := make([]error, len(codes))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < config.workers && w < len(codes); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i], errs[i] = c.GetStockHistoricalDataContext(ctx, codes[i], WithPeriod(period))
+			}
+		}()
+	}
+	for i := range codes {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	panel := &Panel{Codes: []string{}, Errors: map[string]error{}}
+	bars := [][]*OHLC{}
+	for i, code := range codes {
+		if errs[i] != nil {
+			panel.Errors[code] = errs[i]
+			c.logger.Log(LogWarning, "loading panel", Field{Key: "code", Value: code}, Field{Key: "error", Value: errs[i]})
+		}
+		// the bars are kept when some rows are skipped.
+		if len(results[i]) == 0 || errs[i] != nil && !errors.Is(errs[i], ErrSkippedRows) {
+			continue
+		}
+		panel.Codes = append(panel.Codes, code)
+		bars = append(bars, results[i])
+	}
+	panel.align(bars, config.fill)
+	return panel, ctx.Err()
+}
+
+// align is to fill the matrix of the bars of every code by the union of
+// the trading dates.
+func (p *Panel) align(bars [][]*OHLC, fill FillPolicy) {
+	rows := map[TradingDate]int{}
+	for _, codeBars := range bars {
+		for _, bar := range codeBars {
+			rows[bar.TradingDate()] = 0
+		}
+	}
+	p.Dates = make([]TradingDate, 0, len(rows))
+	for date := range rows {
+		p.Dates = append(p.Dates, date)
+	}
+	sort.Slice(p.Dates, func(i, j int) bool {
+		return p.Dates[i].Before(p.Dates[j])
+	})
+	for i, date := range p.Dates {
+		rows[date] = i
+	}
+
+	p.Close, p.Volume, p.Return = nanMatrix(len(p.Dates), len(bars)), nanMatrix(len(p.Dates), len(bars)),
+		nanMatrix(len(p.Dates), len(bars))
+	for j, codeBars := range bars {
+		for _, bar := range codeBars {
+			i := rows[bar.TradingDate()]
+			p.Close[i][j], p.Volume[i][j] = bar.Close, float64(bar.Volume)
+		}
+	}
+	for i := range p.Dates {
+		for j := range bars {
+			if fill == FillForward && i > 0 && math.IsNaN(p.Close[i][j]) && !math.IsNaN(p.Close[i-1][j]) {
+				p.Close[i][j], p.Volume[i][j] = p.Close[i-1][j], 0
+			}
+			if i > 0 {
+				p.Return[i][j] = p.Close[i][j]/p.Close[i-1][j] - 1 // NaN when either is NaN.
+			}
+		}
+	}
+}
+
+// uniqueCodes is the codes without the duplicated ones in the same order.
+func uniqueCodes(codes []string) []string {
+	seen := map[string]bool{}
+	unique := make([]string, 0, len(codes))
+	for _, code := range codes {
+		if !seen[code] {
+			seen[code] = true
+			unique = append(unique, code)
+		}
+	}
+	return unique
+}
+
+// nanMatrix is the matrix of rows x columns filled with NaN.
+func nanMatrix(rows, columns int) [][]float64 {
+	matrix := make([][]float64, rows)
+	for i := range matrix {
+		matrix[i] = make([]float64, columns)
+		for j := range matrix[i] {
+			matrix[i][j] = math.NaN()
+		}
+	}
+	return matrix
+}
@@ -0,0 +1,131 @@
+package klse_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	klse "github.com/kokweikhong/klsescreener-scraper"
+)
+
+// panelRows is the chart rows of the codes, the dates are from 2022-07-04 to 2022-07-08.
+var panelRows = map[string]string{
+	"0001": `[1656864000000,1,1,1,1.00,100],[1656950400000,1,1,1,1.10,200],` +
+		`[1657123200000,1,1,1,1.21,300],[1657209600000,1,1,1,1.21,100],`, // no 07-06
+	"0002": `[1656950400000,2,2,2,2.00,10],[1657036800000,2,2,2,2.20,20],` +
+		`[1657209600000,2,2,2,1.98,30],`, // from 07-05, no 07-07
+	"0004": `[1657123200000,3,3,3,null,4],[1657209600000,3,3,3,3.00,5],[abc,3,3,3,3,5],`, // null close on 07-07
+}
+
+// panelPage is the chart page of panelRows of the requested code, the other
+// codes are the page without chart.
+func panelPage(r *http.Request) string {
+	time.Sleep(10 * time.Millisecond)
+	code := strings.Split(strings.TrimPrefix(r.URL.Path, "/v2/stocks/chart/"), "/")[0]
+	rows, ok := panelRows[code]
+	if !ok {
+		return `<html><body><h1>Stock not found</h1></body></html>`
+	}
+	return chartPage(rows)
+}
+
+// equalFloats is to compare the values with NaN equal to NaN.
+func equalFloats(got, want []float64) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if math.IsNaN(got[i]) != math.IsNaN(want[i]) || !math.IsNaN(got[i]) && math.Abs(got[i]-want[i]) > 1e-9 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestLoadPanel(t *testing.T) {
+	server := newTestServer(panelPage)
+	defer server.Close()
+	client := klse.NewClient(klse.WithBaseURL(server.URL), klse.WithRateLimit(0, 0),
+		klse.WithChartRateLimit(0, 0), klse.WithClock(fixtureClock))
+
+	codes := []string{"0002", "0003", "0001", "0004", "0001"}
+	panel, err := client.LoadPanel(codes, klse.Period1Y, klse.WithWorkers(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if max := server.MaxConcurrent(); max > 2 {
+		t.Errorf("concurrent requests = %d, want 2 at most", max)
+	}
+	if got := strings.Join(panel.Codes, ","); got != "0002,0001,0004" {
+		t.Errorf("codes = %s", got)
+	}
+	if len(panel.Errors) != 2 || !errors.Is(panel.Errors["0003"], klse.ErrNoChartData) ||
+		!errors.Is(panel.Errors["0004"], klse.ErrSkippedRows) {
+		t.Errorf("errors = %v", panel.Errors)
+	}
+	if len(panel.Dates) != 5 || panel.Dates[0].String() != "2022-07-04" || panel.Dates[4].String() != "2022-07-08" {
+		t.Fatalf("dates = %v", panel.Dates)
+	}
+
+	nan := math.NaN()
+	a, b := panel.Column("0001"), panel.Column("0002")
+	if a != 1 || b != 0 || panel.Column("0003") != -1 {
+		t.Fatalf("columns = %d, %d", a, b)
+	}
+	column := func(matrix [][]float64, j int) []float64 {
+		values := []float64{}
+		for _, row := range matrix {
+			values = append(values, row[j])
+		}
+		return values
+	}
+	if got := column(panel.Close, a); !equalFloats(got, []float64{1, 1.1, nan, 1.21, 1.21}) {
+		t.Errorf("close of 0001 = %v", got)
+	}
+	if got := column(panel.Volume, b); !equalFloats(got, []float64{nan, 10, 20, nan, 30}) {
+		t.Errorf("volume of 0002 = %v", got)
+	}
+	if got := column(panel.Return, a); !equalFloats(got, []float64{nan, 0.1, nan, nan, 0}) {
+		t.Errorf("return of 0001 = %v", got)
+	}
+	if got := column(panel.Close, 2); !equalFloats(got, []float64{nan, nan, nan, nan, 3}) {
+		t.Errorf("close of 0004 = %v", got)
+	}
+	if got := column(panel.Volume, 2); !equalFloats(got, []float64{nan, nan, nan, nan, 5}) {
+		t.Errorf("volume of 0004 = %v", got)
+	}
+	if i := panel.Row(klse.TradingDate{Year: 2022, Month: time.July, Day: 8}); i != 4 || panel.Close[i][2] != 3 {
+		t.Errorf("row of 2022-07-08 = %d", i)
+	}
+	if panel.Row(klse.TradingDate{Year: 2022, Month: time.July, Day: 9}) != -1 {
+		t.Error("row of 2022-07-09 is found")
+	}
+
+	panel, _ = client.LoadPanel([]string{"0001", "0002"}, klse.Period1Y, klse.WithFill(klse.FillForward))
+	if got := column(panel.Close, 0); !equalFloats(got, []float64{1, 1.1, 1.1, 1.21, 1.21}) {
+		t.Errorf("filled close of 0001 = %v", got)
+	}
+	if got := column(panel.Volume, 1); !equalFloats(got, []float64{nan, 10, 20, 0, 30}) {
+		t.Errorf("filled volume of 0002 = %v", got)
+	}
+	if got := column(panel.Return, 1); !equalFloats(got, []float64{nan, nan, 0.1, 0, -0.1}) {
+		t.Errorf("filled return of 0002 = %v", got)
+	}
+}
+
+func TestLoadPanelCancelled(t *testing.T) {
+	server := newTestServer(panelPage)
+	defer server.Close()
+	client := klse.NewClient(klse.WithBaseURL(server.URL), klse.WithChartRateLimit(0, 0))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	panel, err := client.LoadPanelContext(ctx, []string{"0001", "0002"}, klse.Period1Y)
+	if !errors.Is(err, context.Canceled) || len(panel.Codes) != 0 || !errors.Is(panel.Errors["0001"], context.Canceled) {
+		t.Errorf("panel = %v, err = %v", panel, err)
+	}
+}
@@ -0,0 +1,146 @@
+package klse
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// parser is the state shared by the page parsers of a request.
+type parser struct {
+	ctx    context.Context
+	logger Logger
+	url    string
+	now    time.Time // time of the request, for the dates without year.
+	fields []Field   // fields for every log, eg url and code.
+	err    error     // first error of the parsing, eg *DriftError.
+	report ParseReport
+	exact  bool // parse the Exact decimals, see WithExactDecimals.
+}
+
+// newParser is to initialise parser for the request url.
+func (c *Client) newParser(ctx context.Context, url string, fields ...Field) *parser {
+	return &parser{
+		ctx:    ctx,
+		logger: c.logger,
+		url:    url,
+		exact:  c.exact,
+		now:    c.clock(),
+		fields: append([]Field{{Key: "url", Value: url}}, fields...),
+	}
+}
+
+// parseDocument is to parse the html page from r with the page parser,
+// the error is wrapped with ErrParse. When the page is parsed with
+// *DriftError, the result is returned together with the error.
+func parseDocument[T any](p *parser, r io.Reader, parse func(doc *goquery.Document) T) (T, error) {
+	var zero T
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return zero, newParseError(p.url, err)
+	}
+	result := parse(doc)
+	if err := p.ctx.Err(); err != nil {
+		return zero, err
+	}
+	return result, p.err
+}
+
+// log is to write log with the parser's fields.
+func (p *parser) log(level LogLevel, msg string, fields ...Field) {
+	p.logger.Log(level, msg, append(append([]Field{}, p.fields...), fields...)...)
+}
+
+// unknownColumn is the name of the column with unknown header.
+const unknownColumn = "\x00unknown"
+
+// tableColumns is the header names of the table columns in order.
+type tableColumns []string
+
+// name is to get the header name of the column i.
+func (c tableColumns) name(i int) string {
+	if i < 0 || i >= len(c) {
+		return unknownColumn
+	}
+	return c[i]
+}
+
+// tableColumns is to map the columns of the table by the thead headers,
+// the headers are matched to expected ignoring case, spaces and punctuation,
+// the empty header is the column without name eg report link. The expected
+// headers are in the order of the original layout of the table, so the
+// relabelled header at the position of the missing expected header, or the
+// table without thead, is mapped by position. The columns mapped by
+// position and the other missing headers are kept as *DriftError, and
+// logged as warning with the unknown headers.
+func (p *parser) tableColumns(name string, table *goquery.Selection, expected ...string) tableColumns {
+	if table.Length() == 0 {
+		return nil
+	}
+	columns := tableColumns{}
+	headers := []string{}
+	found := map[string]bool{}
+	drift := &DriftError{URL: p.url, Table: name}
+	table.First().Find(`thead th`).Each(func(_ int, th *goquery.Selection) {
+		header := removeAllSpaces(th.Text(), " ")
+		column := unknownColumn
+		for _, e := range expected {
+			if !found[e] && normaliseHeader(e) == normaliseHeader(header) {
+				column = e
+				found[e] = true
+				break
+			}
+		}
+		headers = append(headers, header)
+		columns = append(columns, column)
+	})
+	if len(columns) == 0 {
+		drift.MappedByPosition = append([]string{}, expected...)
+		p.log(LogWarning, "schema drift", Field{Key: "table", Value: name},
+			Field{Key: "mapped_by_position", Value: drift.MappedByPosition})
+		if p.err == nil {
+			p.err = drift
+		}
+		return append(tableColumns{}, expected...)
+	}
+	for i, column := range columns {
+		if column != unknownColumn {
+			continue
+		}
+		if len(columns) == len(expected) && !found[expected[i]] {
+			columns[i] = expected[i]
+			found[expected[i]] = true
+			drift.MappedByPosition = append(drift.MappedByPosition, headers[i]+" as "+expected[i])
+			continue
+		}
+		drift.Unknown = append(drift.Unknown, headers[i])
+	}
+	for _, e := range expected {
+		if !found[e] {
+			drift.Missing = append(drift.Missing, e)
+		}
+	}
+	if len(drift.Missing) > 0 || len(drift.Unknown) > 0 || len(drift.MappedByPosition) > 0 {
+		p.log(LogWarning, "schema drift", Field{Key: "table", Value: name}, Field{Key: "missing", Value: drift.Missing},
+			Field{Key: "unknown", Value: drift.Unknown}, Field{Key: "mapped_by_position", Value: drift.MappedByPosition})
+	}
+	if (len(drift.Missing) > 0 || len(drift.MappedByPosition) > 0) && p.err == nil {
+		p.err = drift
+	}
+	return columns
+}
+
+// normaliseHeader is to keep only the lower case letters and digits of the
+// header, eg "MCap.(M)" and "MCap (M)" are "mcapm".
+func normaliseHeader(header string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, header)
+}
@@ -0,0 +1,192 @@
+package klse
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// update is to rewrite the golden files from the parsers' output,
+// eg go test -run TestParserGolden -update
+var update = flag.Bool("update", false, "update the golden files in testdata/golden")
+
+// goldenDir is the directory of the expected parsers' output.
+const goldenDir = "testdata/golden"
+
+// parserNow is the fixed time of the parsing for the dates without year.
+var parserNow = time.Date(2022, time.July, 8, 18, 0, 0, 0, time.UTC)
+
+// newTestParser is to initialise parser with fixed time and no logger.
+func newTestParser() *parser {
+	return &parser{
+		ctx:    context.Background(),
+		logger: nopLogger{},
+		url:    "fixture",
+		now:    parserNow,
+	}
+}
+
+// openFixtureBody is to open the saved page in the fixture of the response.
+func openFixtureBody(t *testing.T, name string) io.Reader {
+	t.Helper()
+	b, err := ioutil.ReadFile(filepath.Join("testdata", "fixtures", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(b)), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bytes.NewReader(body)
+}
+
+// fixtureRecorded is to check the fixture is saved by NewRecordingTransport,
+// ie it has the Date header of the server, not written by hand.
+func fixtureRecorded(t *testing.T, name string) bool {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", "fixtures", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	resp, err := http.ReadResponse(bufio.NewReader(f), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.Header.Get("Date") != ""
+}
+
+func TestParserGolden(t *testing.T) {
+	const stockPage = "GET_v2_stocks_view_6947.http"
+	tests := []struct {
+		golden  string
+		fixture string
+		parse   func(p *parser, r io.Reader) (interface{}, error)
+	}{
+		{"market_information", "GET_v2_markets.http", func(p *parser, r io.Reader) (interface{}, error) {
+			return parseDocument(p, r, p.parseMarketInformation)
+		}},
+		{"quote_results", "POST_v2_screener_quote_results_cc45fa80.http", func(p *parser, r io.Reader) (interface{}, error) {
+			return parseDocument(p, r, p.parseQuoteResults)
+		}},
+		{"dividend_entitlements", "GET_v2_entitlements_dividends.http", func(p *parser, r io.Reader) (interface{}, error) {
+			return parseDocument(p, r, p.parseDividendEntitlements)
+		}},
+		{"share_issued_entitlements", "GET_v2_entitlements_shares-issue.http", func(p *parser, r io.Reader) (interface{}, error) {
+			return parseDocument(p, r, p.parseShareIssuedEntitlements)
+		}},
+		{"quarter_report_announcement", "GET_v2_financial-reports.http", func(p *parser, r io.Reader) (interface{}, error) {
+			return parseDocument(p, r, p.parseQuarterReportAnnouncement)
+		}},
+		{"company_information", stockPage, func(p *parser, r io.Reader) (interface{}, error) {
+			return parseDocument(p, r, p.getCompanyInformation)
+		}},
+		{"company_statistic", stockPage, func(p *parser, r io.Reader) (interface{}, error) {
+			return parseDocument(p, r, p.getCompanyStatistic)
+		}},
+		{"quarter_report", stockPage, func(p *parser, r io.Reader) (interface{}, error) {
+			return parseDocument(p, r, p.getQuarterReport)
+		}},
+		{"annual_report", stockPage, func(p *parser, r io.Reader) (interface{}, error) {
+			return parseDocument(p, r, p.getAnnualReport)
+		}},
+		{"dividends_report", stockPage, func(p *parser, r io.Reader) (interface{}, error) {
+			return parseDocument(p, r, p.getDividendsReport)
+		}},
+		{"capital_changes_report", stockPage, func(p *parser, r io.Reader) (interface{}, error) {
+			return parseDocument(p, r, p.getCapitalChangesReport)
+		}},
+		{"warrants_report", stockPage, func(p *parser, r io.Reader) (interface{}, error) {
+			return parseDocument(p, r, p.getWarrantsReport)
+		}},
+		{"shareholding_changes_report", stockPage, func(p *parser, r io.Reader) (interface{}, error) {
+			return parseDocument(p, r, p.getShareholdingChangesReport)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.golden, func(t *testing.T) {
+			result, err := tt.parse(newTestParser(), openFixtureBody(t, tt.fixture))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, '\n')
+			golden := filepath.Join(goldenDir, tt.golden+".json")
+			if *update {
+				// the golden file of the hand-written page only shows the parser matches the markup written for it.
+				if !fixtureRecorded(t, tt.fixture) {
+					t.Fatalf("%s is hand-written, record it with go test ./... -record before -update", tt.fixture)
+				}
+				if err := os.MkdirAll(goldenDir, 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := ioutil.WriteFile(golden, got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := ioutil.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("%v, run go test -update to create it", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("%s differs from the golden file %s:\ngot:\n%s", tt.golden, golden, got)
+			}
+		})
+	}
+}
+
+func TestParseMagnitude(t *testing.T) {
+	tests := []struct {
+		text    string
+		decimal int
+		want    float64
+		err     error
+	}{
+		{"1.62B", 0, 1620000000, nil},
+		{"245.1M", 0, 245100000, nil},
+		{"12.5k", 0, 12500, nil},
+		{"850", 0, 850, nil},
+		{"1,234.567", 2, 1234.57, nil},
+		{"-", 0, 0, ErrNoValue},
+	}
+	for _, tt := range tests {
+		got, err := parseMagnitude(tt.text, tt.decimal)
+		if got != tt.want || !errors.Is(err, tt.err) {
+			t.Errorf("parseMagnitude(%q) = %v, %v, want %v, %v", tt.text, got, err, tt.want, tt.err)
+		}
+	}
+	if _, err := parseMagnitude("1.2X", 0); err == nil {
+		t.Error("unknown magnitude err = nil")
+	}
+}
+
+func TestParseDocumentContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p := newTestParser()
+	p.ctx = ctx
+	quotes, err := parseDocument(p, openFixtureBody(t, "POST_v2_screener_quote_results_cc45fa80.http"), p.parseQuoteResults)
+	if err != context.Canceled {
+		t.Fatalf("error = %v, want context.Canceled", err)
+	}
+	if quotes != nil {
+		t.Errorf("quotes = %v, want nil", quotes)
+	}
+}
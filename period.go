@@ -0,0 +1,155 @@
+package klse
+
+import (
+	"fmt"
+	"time"
+)
+
+// Period is the period of the historical data up to today, eg Period1Y.
+type Period string
+
+const (
+	Period1M  Period = "1m"
+	Period3M  Period = "3m"
+	Period6M  Period = "6m"
+	Period1Y  Period = "1y"
+	Period3Y  Period = "3y"
+	Period5Y  Period = "5y"
+	Period10Y Period = "10y"
+	PeriodMax Period = "max"
+)
+
+// periods is the preset periods from the shortest to the longest.
+var periods = []Period{Period1M, Period3M, Period6M, Period1Y, Period3Y, Period5Y, Period10Y, PeriodMax}
+
+// months is the length of the period in months, 0 for PeriodMax.
+func (p Period) months() int {
+	switch p {
+	case Period1M:
+		return 1
+	case Period3M:
+		return 3
+	case Period6M:
+		return 6
+	case Period1Y:
+		return 12
+	case Period3Y:
+		return 36
+	case Period5Y:
+		return 60
+	case Period10Y:
+		return 120
+	}
+	return 0
+}
+
+// Valid is to check the period is one of the preset periods.
+func (p Period) Valid() bool {
+	for _, period := range periods {
+		if p == period {
+			return true
+		}
+	}
+	return false
+}
+
+// Start is the first date of the period up to today, zero for PeriodMax.
+func (p Period) Start(today TradingDate) TradingDate {
+	months := p.months()
+	if months == 0 {
+		return TradingDate{}
+	}
+	return NewTradingDate(today.Time().AddDate(0, -months, 0))
+}
+
+// ShortestPeriod is the shortest preset period up to today which covers
+// the date from, PeriodMax when from is zero or older than 10 years.
+func ShortestPeriod(from, today TradingDate) Period {
+	if from.IsZero() {
+		return PeriodMax
+	}
+	for _, period := range periods {
+		if !period.Start(today).After(from) {
+			return period
+		}
+	}
+	return PeriodMax
+}
+
+// HistoricalOption is the option of the historical data requests.
+type HistoricalOption func(*historicalRange)
+
+// WithPeriod is the option to get the historical data of the period up to
+// today, it is Period10Y by default. The period which is not one of the
+// preset periods is ErrInvalidPeriod.
+func WithPeriod(period Period) HistoricalOption {
+	return func(r *historicalRange) {
+		r.period = period
+		r.from, r.to = TradingDate{}, TradingDate{}
+	}
+}
+
+// WithDateRange is the option to get the historical data from and to the
+// dates inclusive, zero to means up to today. The shortest period covering
+// the range is requested and the data outside the range are trimmed.
+func WithDateRange(from, to TradingDate) HistoricalOption {
+	return func(r *historicalRange) {
+		r.period = ""
+		r.from, r.to = from, to
+	}
+}
+
+// historicalRange is the period requested from the server and the dates of
+// the data kept, the zero from and to are unbounded.
+type historicalRange struct {
+	period Period
+	from   TradingDate
+	to     TradingDate
+	trim   bool
+}
+
+// newHistoricalRange is to resolve the options against today of the clock,
+// the data are trimmed only when any option is given.
+func (c *Client) newHistoricalRange(opts []HistoricalOption) (historicalRange, error) {
+	r := historicalRange{period: Period10Y}
+	if len(opts) == 0 {
+		return r, nil
+	}
+	for _, opt := range opts {
+		opt(&r)
+	}
+	today := NewTradingDate(c.clock())
+	switch {
+	case r.period == "":
+		r.period = ShortestPeriod(r.from, today)
+	case !r.period.Valid():
+		return r, fmt.Errorf("%w: %q", ErrInvalidPeriod, r.period)
+	default:
+		r.from = r.period.Start(today)
+	}
+	r.trim = true
+	return r, nil
+}
+
+// contains is to check the date is within the range.
+func (r historicalRange) contains(date time.Time) bool {
+	if !r.trim {
+		return true
+	}
+	d := NewTradingDate(date)
+	return !d.Before(r.from) && (r.to.IsZero() || !d.After(r.to))
+}
+
+// trimHistorical is to remove the rows which dates are outside the range.
+func trimHistorical[T any](rows []T, r historicalRange, date func(T) time.Time) []T {
+	if !r.trim {
+		return rows
+	}
+	trimmed := rows[:0]
+	for _, row := range rows {
+		if r.contains(date(row)) {
+			trimmed = append(trimmed, row)
+		}
+	}
+	return trimmed
+}
@@ -0,0 +1,149 @@
+package klse_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	klse "github.com/kokweikhong/klsescreener-scraper"
+	"github.com/kokweikhong/klsescreener-scraper/keys"
+)
+
+func TestShortestPeriod(t *testing.T) {
+	today := klse.TradingDate{Year: 2022, Month: time.July, Day: 8}
+	tests := []struct {
+		from klse.TradingDate
+		want klse.Period
+	}{
+		{klse.TradingDate{}, klse.PeriodMax},
+		{klse.TradingDate{Year: 2022, Month: time.July, Day: 7}, klse.Period1M},
+		{klse.TradingDate{Year: 2022, Month: time.June, Day: 8}, klse.Period1M},
+		{klse.TradingDate{Year: 2022, Month: time.June, Day: 7}, klse.Period3M},
+		{klse.TradingDate{Year: 2021, Month: time.December, Day: 31}, klse.Period1Y},
+		{klse.TradingDate{Year: 2018, Month: time.January, Day: 2}, klse.Period5Y},
+		{klse.TradingDate{Year: 2012, Month: time.July, Day: 8}, klse.Period10Y},
+		{klse.TradingDate{Year: 2001, Month: time.January, Day: 2}, klse.PeriodMax},
+	}
+	for _, tt := range tests {
+		if got := klse.ShortestPeriod(tt.from, today); got != tt.want {
+			t.Errorf("ShortestPeriod(%v) = %v, want %v", tt.from, got, tt.want)
+		}
+	}
+}
+
+// periodRows is the chart of daily bars from 2022-05-31 to 2022-07-08.
+const periodRows = `[1653926400000,1,2,0.5,1.5,100],` + // 2022-05-31
+	`[1654012800000,1,2,0.5,1.5,100],` + // 2022-06-01
+	`[1656604800000,1,2,0.5,1.5,100],` + // 2022-07-01
+	`[1657209600000,1,2,0.5,1.5,100],` // 2022-07-08
+
+func TestHistoricalPeriod(t *testing.T) {
+	server := chartPageServer(periodRows)
+	defer server.Close()
+	client := klse.NewClient(klse.WithBaseURL(server.URL), klse.WithChartRateLimit(0, 0), klse.WithClock(fixtureClock))
+
+	data, err := client.GetStockHistoricalData("7251", klse.WithPeriod(klse.Period1M))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 2 || data[0].TradingDate().String() != "2022-07-01" {
+		t.Errorf("1m data = %d, first = %+v", len(data), data[0])
+	}
+
+	from := klse.TradingDate{Year: 2022, Month: time.June, Day: 1}
+	to := klse.TradingDate{Year: 2022, Month: time.July, Day: 1}
+	data, err = client.GetStockHistoricalData("7251", klse.WithDateRange(from, to))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 2 || data[0].TradingDate() != from || data[1].TradingDate() != to {
+		t.Errorf("range data = %d", len(data))
+	}
+
+	data, err = client.GetStockHistoricalData("7251")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 4 {
+		t.Errorf("default data = %d, want 4", len(data))
+	}
+
+	want := []string{
+		"/v2/stocks/chart/7251/embedded/1m",
+		"/v2/stocks/chart/7251/embedded/3m",
+		"/v2/stocks/chart/7251/embedded/10y",
+	}
+	paths := server.Paths()
+	for i, path := range want {
+		if paths[i] != path {
+			t.Errorf("path[%d] = %s, want %s", i, paths[i], path)
+		}
+	}
+}
+
+func TestInvalidPeriod(t *testing.T) {
+	server := chartPageServer(periodRows)
+	defer server.Close()
+	client := klse.NewClient(klse.WithBaseURL(server.URL), klse.WithChartRateLimit(0, 0), klse.WithClock(fixtureClock))
+
+	if klse.Period("2y").Valid() || !klse.Period3Y.Valid() || !klse.PeriodMax.Valid() {
+		t.Error("Valid() of the periods")
+	}
+	fetchers := map[string]func() error{
+		"stock": func() error {
+			_, err := client.GetStockHistoricalData("7251", klse.WithPeriod("2y"))
+			return err
+		},
+		"bursa": func() error {
+			_, err := client.GetBursaIndexHistoricalData(keys.PROPERTY, klse.WithPeriod("5d"))
+			return err
+		},
+		"market": func() error {
+			_, err := client.GetMarketIndexHistoricalData(keys.GOLD, klse.WithPeriod("../10y"))
+			return err
+		},
+		"panel": func() error {
+			_, err := client.LoadPanel([]string{"7251"}, "2y")
+			return err
+		},
+	}
+	for name, fetch := range fetchers {
+		if err := fetch(); !errors.Is(err, klse.ErrInvalidPeriod) {
+			t.Errorf("%s err = %v, want ErrInvalidPeriod", name, err)
+		}
+	}
+	if paths := server.Paths(); len(paths) != 0 {
+		t.Errorf("requested = %v, want no request", paths)
+	}
+}
+
+func TestBursaIndexHistoricalPeriod(t *testing.T) {
+	client := newFixtureClient(t, klse.WithClock(fixtureClock))
+	data, err := client.GetBursaIndexHistoricalData(keys.PROPERTY, klse.WithDateRange(
+		klse.TradingDate{Year: 2022, Month: time.June, Day: 28}, klse.TradingDate{Year: 2022, Month: time.June, Day: 29}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ohlc := range data {
+		if date := ohlc.TradingDate().String(); date < "2022-06-28" || date > "2022-06-29" {
+			t.Errorf("date %s is outside the range", date)
+		}
+	}
+	if len(data) == 0 {
+		t.Error("data is empty")
+	}
+}
+
+func TestMarketIndexHistoricalPeriod(t *testing.T) {
+	server := chartPageServer(`[1654012800000,1800,10],[1657209600000,1822.8,12],`)
+	defer server.Close()
+	client := klse.NewClient(klse.WithBaseURL(server.URL), klse.WithClock(fixtureClock))
+
+	data, err := client.GetMarketIndexHistoricalData(keys.GOLD, klse.WithPeriod(klse.PeriodMax))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if paths := server.Paths(); len(data) != 2 || paths[0] != "/v2/markets/historical_period/GC=F/max" {
+		t.Errorf("data = %d, path = %v", len(data), paths)
+	}
+}
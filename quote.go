@@ -1,9 +1,9 @@
 package klse
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -26,15 +26,34 @@ type QuoteResult struct {
 		Low  float64 `json:"low"`
 		High float64 `json:"high"`
 	} `json:"52_week"`
-	Volume        int     `json:"volume"`
-	EPS           float64 `json:"eps"`
-	DPS           float64 `json:"dps"`
-	NTA           float64 `json:"nta"`
-	PE            float64 `json:"pe"`
-	DY            float64 `json:"dy"`
-	ROE           float64 `json:"roe"`
-	PTBV          float64 `json:"ptbv"`
-	MarketCapital int     `json:"market_capital"`
+	Volume        int               `json:"volume"`
+	EPS           float64           `json:"eps"`
+	DPS           float64           `json:"dps"`
+	NTA           float64           `json:"nta"`
+	PE            float64           `json:"pe"`
+	DY            float64           `json:"dy"`
+	ROE           float64           `json:"roe"`
+	PTBV          float64           `json:"ptbv"`
+	MarketCapital int               `json:"market_capital"`
+	Report        ParseReport       `json:"parse_report,omitempty"` // fields can't be parsed, eg PE "-".
+	Exact         *ExactQuoteResult `json:"exact,omitempty"`        // with WithExactDecimals only.
+}
+
+// ExactQuoteResult is the exact decimal prices and figures of quote result.
+type ExactQuoteResult struct {
+	Price        Decimal `json:"price"`
+	Changes      Decimal `json:"changes"`
+	FiftyTwoWeek struct {
+		Low  Decimal `json:"low"`
+		High Decimal `json:"high"`
+	} `json:"52_week"`
+	EPS  Decimal `json:"eps"`
+	DPS  Decimal `json:"dps"`
+	NTA  Decimal `json:"nta"`
+	PE   Decimal `json:"pe"`
+	DY   Decimal `json:"dy"`
+	ROE  Decimal `json:"roe"`
+	PTBV Decimal `json:"ptbv"`
 }
 
 // quote is to create new request and options for quote result function.
@@ -43,93 +62,138 @@ type QuoteResult struct {
 //	 quote.WithMinPE(1),
 //	 quote.WithMinROE(20),
 // )
-type quote struct{}
+type quote struct {
+	client *Client
+}
 
-// NewQuoteResultRequest is to initialise quote to create new request.
+// NewQuoteResultRequest is to initialise quote to create new request
+// with the default client.
 func NewQuoteResultRequest() *quote {
-	return &quote{}
+	return DefaultClient.NewQuoteResultRequest()
+}
+
+// NewQuoteResultRequest is to initialise quote to create new request.
+func (c *Client) NewQuoteResultRequest() *quote {
+	return &quote{client: c}
 }
 
 // GetQuoteResults is to get quote results.
 // options = function start with "With".
-func (*quote) GetQuoteResults(options ...quoteOption) ([]*QuoteResult, error) {
+func (q *quote) GetQuoteResults(options ...quoteOption) ([]*QuoteResult, error) {
+	return q.GetQuoteResultsContext(context.Background(), options...)
+}
+
+// GetQuoteResultsContext is GetQuoteResults with context,
+// the parsing will stop when the context is cancelled.
+func (q *quote) GetQuoteResultsContext(ctx context.Context, options ...quoteOption) ([]*QuoteResult, error) {
 	quotes := []*QuoteResult{}
 	op := newQuoteParams(options...)
 	data, err := op.generateURLRequestValues()
 	if err != nil {
 		return quotes, err
 	}
-	u := "https://www.klsescreener.com/v2/screener/quote_results"
 
 	// need to set content type application/x-www-form-urlencoded for header
 	contentType := map[string]string{
 		"content-type": "application/x-www-form-urlencoded; charset=UTF-8",
 	}
 
-	resp := newRequest(http.MethodPost, u, strings.NewReader(data.Encode()), contentType)
-	defer resp.Body.Close()
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	url := q.client.baseURL + "/v2/screener/quote_results"
+	p := q.client.newParser(ctx, url)
+	resp, err := q.client.newRequest(ctx, http.MethodPost, "/v2/screener/quote_results", strings.NewReader(data.Encode()), contentType)
 	if err != nil {
 		return quotes, err
 	}
-	doc.Find(`tbody tr.list`).Each(func(index int, children *goquery.Selection) {
-		log.Printf("[GET] getting number %d data...", index+1)
+	defer resp.Body.Close()
+	return parseDocument(p, resp.Body, p.parseQuoteResults)
+}
+
+// parseQuoteResults is to parse the quote results table,
+// the parsing will stop when the context is cancelled.
+func (p *parser) parseQuoteResults(doc *goquery.Document) []*QuoteResult {
+	quotes := []*QuoteResult{}
+	table := doc.Find(`table`)
+	columns := p.tableColumns("quote_results", table,
+		"Name", "Code", "Category", "Price", "Change%", "52week", "Volume", "EPS", "DPS", "NTA", "PE", "DY", "ROE", "PTBV", "MCap.(M)")
+	table.Find(`tbody tr.list`).EachWithBreak(func(index int, children *goquery.Selection) bool {
+		if p.ctx.Err() != nil {
+			return false
+		}
 		quote := &QuoteResult{}
+		exact := &ExactQuoteResult{}
 		children.Find(`td`).Each(func(i int, element *goquery.Selection) {
 			text := regexpSpaces.ReplaceAllString(element.Text(), " ")
 			text = strings.TrimSpace(text)
-			switch i {
-			case 0:
+			switch columns.name(i) {
+			case "Name":
 				quote.ShortName = strings.Replace(text, "[s]", "", 1)
 				quote.Name, _ = element.Attr("title")
-			case 1:
+			case "Code":
 				quote.Code = text
-			case 2:
+			case "Category":
 				if strings.Contains(text, ",") && len(strings.Split(text, ",")) > 1 {
 					splitCategory := strings.Split(text, ",")
 					quote.Market = splitCategory[1]
 					quote.Category = splitCategory[0]
 				}
-			case 3:
-				quote.Price = convertStringToFloat64(text, 3)
-			case 4:
+			case "Price":
+				quote.Price = p.float("price", text, 3)
+				exact.Price = p.decimal("price", text)
+			case "Change%":
 				text = strings.ReplaceAll(text, "%", "")
-				quote.Changes = convertStringToFloat64(text, 1)
-			case 5:
+				quote.Changes = p.float("changes", text, 1)
+				exact.Changes = p.decimal("changes", text)
+			case "52week":
 				split52Week := strings.Split(text, "-")
-				if len(split52Week) > 1 {
+				if len(split52Week) != 2 {
+					p.issue("52_week", text, ErrNoValue)
+				} else {
 					quote.FiftyTwoWeek = struct {
 						Low  float64 "json:\"low\""
 						High float64 "json:\"high\""
 					}{
-						convertStringToFloat64(split52Week[0], 3),
-						convertStringToFloat64(split52Week[1], 3),
+						p.float("52_week.low", split52Week[0], 3),
+						p.float("52_week.high", split52Week[1], 3),
 					}
+					exact.FiftyTwoWeek.Low = p.decimal("52_week.low", split52Week[0])
+					exact.FiftyTwoWeek.High = p.decimal("52_week.high", split52Week[1])
 				}
-			case 6:
-				quote.Volume = int(convertStringToFloat64(text, 0))
-			case 7:
-				quote.EPS = convertStringToFloat64(text, 2)
-			case 8:
-				quote.DPS = convertStringToFloat64(text, 2)
-			case 9:
-				quote.NTA = convertStringToFloat64(text, 3)
-			case 10:
-				quote.PE = convertStringToFloat64(text, 2)
-			case 11:
-				quote.DY = convertStringToFloat64(text, 2)
-			case 12:
-				quote.ROE = convertStringToFloat64(text, 2)
-			case 13:
-				quote.PTBV = convertStringToFloat64(text, 2)
-			case 14:
-				quote.MarketCapital = int(convertStringToFloat64(text, 2) * 1000000)
+			case "Volume":
+				quote.Volume = p.integer("volume", text)
+			case "EPS":
+				quote.EPS = p.float("eps", text, 2)
+				exact.EPS = p.decimal("eps", text)
+			case "DPS":
+				quote.DPS = p.float("dps", text, 2)
+				exact.DPS = p.decimal("dps", text)
+			case "NTA":
+				quote.NTA = p.float("nta", text, 3)
+				exact.NTA = p.decimal("nta", text)
+			case "PE":
+				quote.PE = p.float("pe", text, 2)
+				exact.PE = p.decimal("pe", text)
+			case "DY":
+				quote.DY = p.float("dy", text, 2)
+				exact.DY = p.decimal("dy", text)
+			case "ROE":
+				quote.ROE = p.float("roe", text, 2)
+				exact.ROE = p.decimal("roe", text)
+			case "PTBV":
+				quote.PTBV = p.float("ptbv", text, 2)
+				exact.PTBV = p.decimal("ptbv", text)
+			case "MCap.(M)":
+				quote.MarketCapital = int(p.float("market_capital", text, 2) * 1000000)
 			}
 		})
+		quote.Report = p.takeReport()
+		if p.exact {
+			quote.Exact = exact
+		}
 		quotes = append(quotes, quote)
-		logInfo.Printf("%d. %v\n", index, quote)
+		p.log(LogDebug, "getting quote result", Field{Key: "row", Value: index}, Field{Key: "data", Value: quote})
+		return true
 	})
-	return quotes, nil
+	return quotes
 }
 
 // quoteParams is the options to filter quote results.
@@ -184,12 +248,10 @@ func (qp *quoteParams) generateURLRequestValues() (url.Values, error) {
 	data := url.Values{}
 	b, err := json.Marshal(qp)
 	if err != nil {
-		logError.Println(err)
 		return data, err
 	}
 	var mapRequest map[string]interface{}
 	if err = json.Unmarshal(b, &mapRequest); err != nil {
-		logError.Println(err)
 		return data, err
 	}
 	for k, v := range mapRequest {
@@ -1,18 +1,100 @@
 package klse_test
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	klse "github.com/kokweikhong/klsescreener-scraper"
 	"github.com/kokweikhong/klsescreener-scraper/keys"
 )
 
+// quoteResultsHead is the column headers of the quote results table.
+const quoteResultsHead = `<thead><tr><th>Name</th><th>Code</th><th>Category</th><th>Price</th>` +
+	`<th>Change%</th><th>52week</th><th>Volume</th><th>EPS</th><th>DPS</th><th>NTA</th><th>PE</th>` +
+	`<th>DY</th><th>ROE</th><th>PTBV</th><th>MCap.(M)</th></tr></thead>`
+
 func TestGetQuoteResults(t *testing.T) {
-	newRequest := klse.NewQuoteResultRequest()
-	newRequest.GetQuoteResults(
+	newRequest := newFixtureClient(t).NewQuoteResultRequest()
+	results, err := newRequest.GetQuoteResults(
 		// newRequest.WithMinPE(1),
 		// newRequest.WithMaxPE(3),
 		// newRequest.WithStockTags("0001", "6947"),
 		newRequest.WithBoard(keys.B_ACE_MARKET),
 	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("results = %d, want 2", len(results))
+	}
+	result := results[0]
+	if result.Name != "GREATECH TECHNOLOGY BHD" || strings.TrimSpace(result.ShortName) != "GREATEC" || result.Code != "0208" ||
+		result.Market != "ACE Market" || result.Category != "Technology" {
+		t.Errorf("result = %+v", result)
+	}
+	if result.Price != 4.85 || result.Changes != 2.1 || result.FiftyTwoWeek.Low != 3.8 || result.FiftyTwoWeek.High != 6.79 ||
+		result.Volume != 1234500 || result.EPS != 8.62 || result.NTA != 0.45 || result.PE != 56.26 ||
+		result.ROE != 19.16 || result.PTBV != 10.78 {
+		t.Errorf("result = %+v", result)
+	}
+	if results[1].PE != 0 || results[1].EPS != -0.94 {
+		t.Errorf("result = %+v", results[1])
+	}
+}
+
+func TestGetQuoteResultsSchemaDrift(t *testing.T) {
+	// new column "RSI" is added before "Price" and "NTA" is removed.
+	head := strings.Replace(quoteResultsHead, "<th>Price</th>", "<th>RSI</th><th>Price</th>", 1)
+	head = strings.Replace(head, "<th>NTA</th>", "", 1)
+	server := pageServer(`<table>` + head + `<tbody><tr class="list"><td title="A BHD">A</td><td>0001</td>` +
+		`<td>Technology,Main Market</td><td>55.3</td><td>1.250</td><td>2.1%</td></tr></tbody></table>`)
+	defer server.Close()
+
+	quote := klse.NewClient(klse.WithBaseURL(server.URL)).NewQuoteResultRequest()
+	results, err := quote.GetQuoteResults()
+	var drift *klse.DriftError
+	if !errors.As(err, &drift) || !errors.Is(err, klse.ErrSchemaDrift) {
+		t.Fatalf("error = %v, want *DriftError", err)
+	}
+	if drift.Table != "quote_results" || len(drift.Missing) != 1 || drift.Missing[0] != "NTA" ||
+		len(drift.Unknown) != 1 || drift.Unknown[0] != "RSI" {
+		t.Errorf("drift = %+v", drift)
+	}
+	if len(results) != 1 || results[0].Price != 1.25 || results[0].Changes != 2.1 || results[0].Market != "Main Market" {
+		t.Errorf("results = %+v, want the columns mapped by headers", results)
+	}
+}
+
+func TestGetQuoteResultsRelabelledHeaders(t *testing.T) {
+	row := `<tbody><tr class="list"><td title="A BHD">A</td><td>0001</td><td>Technology,Main Market</td>` +
+		`<td>1.250</td><td>2.1%</td></tr></tbody>`
+	// "Change%" and "MCap.(M)" are relabelled in the same position, "52week" has punctuation.
+	head := strings.Replace(quoteResultsHead, "<th>Change%</th>", "<th>Chg %</th>", 1)
+	head = strings.Replace(head, "<th>MCap.(M)</th>", "<th>Market Cap</th>", 1)
+	head = strings.Replace(head, "<th>52week</th>", "<th>52-Week</th>", 1)
+	tests := []struct {
+		name, page string
+		positional []string
+	}{
+		{"relabelled", `<table>` + head + row + `</table>`, []string{"Chg % as Change%", "Market Cap as MCap.(M)"}},
+		{"replaced", `<table>` + strings.Replace(quoteResultsHead, "<th>PE</th>", "<th>PEG</th>", 1) + row + `</table>`,
+			[]string{"PEG as PE"}},
+		{"no thead", `<table>` + row + `</table>`, nil},
+	}
+	for _, tt := range tests {
+		server := pageServer(tt.page)
+		quote := klse.NewClient(klse.WithBaseURL(server.URL)).NewQuoteResultRequest()
+		results, err := quote.GetQuoteResults()
+		var drift *klse.DriftError
+		if !errors.As(err, &drift) || !errors.Is(err, klse.ErrSchemaDrift) || len(drift.Missing) != 0 ||
+			len(drift.MappedByPosition) == 0 || tt.positional != nil && fmt.Sprint(drift.MappedByPosition) != fmt.Sprint(tt.positional) {
+			t.Errorf("%s: err = %v, want the columns mapped by position as *DriftError", tt.name, err)
+		}
+		if len(results) != 1 || results[0].Price != 1.25 || results[0].Changes != 2.1 {
+			t.Errorf("%s: results = %+v", tt.name, results)
+		}
+		server.Close()
+	}
 }
@@ -0,0 +1,105 @@
+package klse
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRateLimit      = 2   // requests per second for all the requests.
+	defaultRateBurst      = 5   // requests allowed at once for all the requests.
+	defaultChartRateLimit = 0.5 // requests per second for /stocks/chart/ requests.
+	defaultChartRateBurst = 2   // requests allowed at once for /stocks/chart/ requests.
+)
+
+// rateLimiter is the token bucket shared by all the goroutines
+// using the same client. nil rateLimiter is unlimited.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens added per second
+	burst  float64 // maximum tokens in the bucket
+	tokens float64 // available tokens, negative is reserved by waiting requests
+	last   time.Time
+}
+
+// newRateLimiter is to initialise the token bucket,
+// rate 0 or less is unlimited.
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait is to take a token from the bucket, it blocks until the token
+// is available or the context is done.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	// reserve the token first, so the waiting requests are in order.
+	l.tokens--
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+	if err := sleep(ctx, delay); err != nil {
+		// give back the reserved token.
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// WithRateLimit is the option to limit the requests per second of the client,
+// burst is the requests allowed at once. rate 0 or less is unlimited.
+// Default is 2 requests per second with burst of 5.
+func WithRateLimit(rate float64, burst int) ClientOption {
+	return func(c *Client) {
+		c.limiter = newRateLimiter(rate, burst)
+	}
+}
+
+// WithChartRateLimit is the option to limit the requests per second of
+// the heavy /stocks/chart/ requests eg GetStockHistoricalData,
+// the requests also count towards WithRateLimit. rate 0 or less is unlimited.
+// Default is 1 request every 2 seconds with burst of 2.
+func WithChartRateLimit(rate float64, burst int) ClientOption {
+	return func(c *Client) {
+		c.chartLimiter = newRateLimiter(rate, burst)
+	}
+}
+
+// waitRateLimit is to wait for the rate limiters of the request path,
+// the daily and intraday charts share the chart rate limiter.
+func (c *Client) waitRateLimit(ctx context.Context, path string) error {
+	if endpoint := endpointOf(path); endpoint == EndpointChart || endpoint == EndpointIntraday {
+		if err := c.chartLimiter.wait(ctx); err != nil {
+			return err
+		}
+	}
+	return c.limiter.wait(ctx)
+}
@@ -0,0 +1,85 @@
+package klse_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	klse "github.com/kokweikhong/klsescreener-scraper"
+)
+
+func TestRateLimitSharedByGoroutines(t *testing.T) {
+	server := chartPageServer(`[1656288000000,1,1,1,1,100],`)
+	defer server.Close()
+
+	client := klse.NewClient(
+		klse.WithBaseURL(server.URL),
+		klse.WithRateLimit(20, 1),
+	)
+	start := time.Now()
+	wg := sync.WaitGroup{}
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetCompanyOverview("0001"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	// first request uses the burst, the next 4 requests wait 50ms each.
+	if elapsed := time.Since(start); elapsed < 180*time.Millisecond {
+		t.Errorf("5 requests took %v, want at least 200ms", elapsed)
+	}
+}
+
+func TestChartRateLimit(t *testing.T) {
+	server := chartPageServer(`[1656288000000,1,1,1,1,100],`)
+	defer server.Close()
+
+	client := klse.NewClient(
+		klse.WithBaseURL(server.URL),
+		klse.WithRateLimit(0, 0),
+		klse.WithChartRateLimit(10, 1),
+	)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetCompanyOverview("0001"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("unlimited requests took %v", elapsed)
+	}
+
+	start = time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetStockHistoricalData("0001"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 180*time.Millisecond {
+		t.Errorf("3 chart requests took %v, want at least 200ms", elapsed)
+	}
+}
+
+func TestRateLimitContext(t *testing.T) {
+	server := chartPageServer(`[1656288000000,1,1,1,1,100],`)
+	defer server.Close()
+
+	client := klse.NewClient(
+		klse.WithBaseURL(server.URL),
+		klse.WithRateLimit(0.1, 1),
+	)
+	if _, err := client.GetMarketInformation(); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetMarketInformationContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
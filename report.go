@@ -0,0 +1,139 @@
+package klse
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ParseIssue is the field which raw text can't be parsed, the field is
+// left as zero value. errors.Is(issue, ErrNoValue) is true when the raw
+// text is empty or "-", eg PE of the loss making company.
+type ParseIssue struct {
+	Field string // json name of the field, eg "pe", "quarter_reports[0].eps".
+	Raw   string // raw text of the cell.
+	Err   error
+}
+
+// Error is to implement error interface.
+func (i *ParseIssue) Error() string {
+	return fmt.Sprintf("%s: %q : %s", i.Field, i.Raw, i.Err)
+}
+
+// Unwrap is to get the error of the parsing.
+func (i *ParseIssue) Unwrap() error {
+	return i.Err
+}
+
+// MarshalJSON is to render the error as string.
+func (i *ParseIssue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Field string `json:"field"`
+		Raw   string `json:"raw"`
+		Error string `json:"error"`
+	}{i.Field, i.Raw, i.Err.Error()})
+}
+
+// ParseReport is the parse issues of a result, it is empty when all the
+// fields are parsed, so "PE is 0" can be told apart from "PE is -".
+type ParseReport []*ParseIssue
+
+// Issue is to get the parse issue of the field, nil when it is parsed.
+func (r ParseReport) Issue(field string) *ParseIssue {
+	for _, issue := range r {
+		if issue.Field == field {
+			return issue
+		}
+	}
+	return nil
+}
+
+// Valid is to check the field is parsed without issue.
+func (r ParseReport) Valid(field string) bool {
+	return r.Issue(field) == nil
+}
+
+// Float64 is to get the value of the field as nullable,
+// nil when the field has parse issue.
+// example : pe := result.Report.Float64("pe", result.PE)
+func (r ParseReport) Float64(field string, value float64) *float64 {
+	if !r.Valid(field) {
+		return nil
+	}
+	return &value
+}
+
+// Err is to get the parse issues as error, nil when there is no issue.
+func (r ParseReport) Err() error {
+	switch len(r) {
+	case 0:
+		return nil
+	case 1:
+		return r[0]
+	}
+	return fmt.Errorf("%w and %d other parse issues", r[0], len(r)-1)
+}
+
+// issue is to add the parse issue of the field to the report,
+// ErrNoValue is logged as debug and the others as warning.
+func (p *parser) issue(field, raw string, err error) {
+	p.report = append(p.report, &ParseIssue{Field: field, Raw: raw, Err: err})
+	level := LogWarning
+	if errors.Is(err, ErrNoValue) {
+		level = LogDebug
+	}
+	p.log(level, "parse issue", Field{Key: "field", Value: field},
+		Field{Key: "raw", Value: raw}, Field{Key: "error", Value: err})
+}
+
+// takeReport is to get the parse issues since the last taken.
+func (p *parser) takeReport() ParseReport {
+	report := p.report
+	p.report = nil
+	return report
+}
+
+// float is to convert the text of the field to float64,
+// decimal is after convert how many places of decimal needed.
+func (p *parser) float(field, text string, decimal int) float64 {
+	number, err := parseFloat64(text, decimal)
+	if err != nil {
+		p.issue(field, text, err)
+	}
+	return number
+}
+
+// integer is to convert the text of the field to int.
+func (p *parser) integer(field, text string) int {
+	return int(p.float(field, text, 0))
+}
+
+// magnitude is to convert the text of the field with "K", "M", "B" to float64.
+func (p *parser) magnitude(field, text string, decimal int) float64 {
+	number, err := parseMagnitude(text, decimal)
+	if err != nil {
+		p.issue(field, text, err)
+	}
+	return number
+}
+
+// date is to convert the text of the field to time.Time in MYT with the layout.
+func (p *parser) date(field, text, layout string) time.Time {
+	if isNoValue(text) {
+		p.issue(field, text, ErrNoValue)
+		return time.Time{}
+	}
+	date, err := time.ParseInLocation(layout, text, MYT)
+	if err != nil {
+		p.issue(field, text, err)
+	}
+	return date
+}
+
+// elementField is to get the field name prefix of the element i in the list,
+// eg "quarter_reports[0]." for the fields of first quarter report.
+func elementField(list string, i int) string {
+	return list + "[" + strconv.Itoa(i) + "]."
+}
@@ -0,0 +1,52 @@
+package klse_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	klse "github.com/kokweikhong/klsescreener-scraper"
+)
+
+func TestParseReport(t *testing.T) {
+	server := pageServer(`<table>` + quoteResultsHead + `<tbody><tr class="list"><td title="A BHD">A</td><td>0001</td>` +
+		`<td>Technology,Main Market</td><td>1.250</td><td>0.0%</td><td>1.000-1.500</td><td>1,000</td>` +
+		`<td>-</td><td>0.00</td><td>0.50</td><td>-</td><td>0.00</td><td>abc</td><td>2.50</td><td>100.00</td></tr></tbody></table>`)
+	defer server.Close()
+
+	quote := klse.NewClient(klse.WithBaseURL(server.URL)).NewQuoteResultRequest()
+	results, err := quote.GetQuoteResults()
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := results[0]
+	if len(result.Report) != 3 {
+		t.Fatalf("parse report = %v, want eps, pe and roe", result.Report)
+	}
+
+	// PE is "-" and DY is 0, both are 0 but only DY is valid.
+	if result.PE != 0 || result.DY != 0 || result.Report.Valid("pe") || !result.Report.Valid("dy") {
+		t.Errorf("pe = %v valid %v, dy = %v valid %v", result.PE, result.Report.Valid("pe"), result.DY, result.Report.Valid("dy"))
+	}
+	if pe, dy := result.Report.Float64("pe", result.PE), result.Report.Float64("dy", result.DY); pe != nil || dy == nil || *dy != 0 {
+		t.Errorf("nullable pe = %v, dy = %v", pe, dy)
+	}
+	if issue := result.Report.Issue("pe"); issue == nil || issue.Raw != "-" || !errors.Is(issue, klse.ErrNoValue) {
+		t.Errorf("pe issue = %v, want ErrNoValue", issue)
+	}
+	if issue := result.Report.Issue("roe"); issue == nil || issue.Raw != "abc" || errors.Is(issue, klse.ErrNoValue) {
+		t.Errorf("roe issue = %v, want syntax error", issue)
+	}
+	if err := result.Report.Err(); err == nil || !strings.Contains(err.Error(), "2 other parse issues") {
+		t.Errorf("report error = %v", err)
+	}
+
+	b, err := json.Marshal(result.Report.Issue("pe"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"field":"pe","raw":"-","error":"klse: no value"}` {
+		t.Errorf("json = %s", b)
+	}
+}
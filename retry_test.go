@@ -0,0 +1,139 @@
+package klse_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	klse "github.com/kokweikhong/klsescreener-scraper"
+)
+
+// failingServer is the test server which response the status
+// for the first failures requests.
+func failingServer(failures int32, status int, retryAfter string) (*httptest.Server, *int32) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) <= failures {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`<html><body></body></html>`))
+	}))
+	return server, &attempts
+}
+
+func TestRetryGet(t *testing.T) {
+	server, attempts := failingServer(2, http.StatusServiceUnavailable, "")
+	defer server.Close()
+
+	client := klse.NewClient(
+		klse.WithBaseURL(server.URL),
+		klse.WithRetryPolicy(klse.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}),
+	)
+	if _, err := client.GetMarketInformation(); err != nil {
+		t.Fatal(err)
+	}
+	if *attempts != 3 {
+		t.Errorf("attempts = %d, want 3", *attempts)
+	}
+}
+
+func TestRetryMaxAttempts(t *testing.T) {
+	server, attempts := failingServer(5, http.StatusBadGateway, "")
+	defer server.Close()
+
+	client := klse.NewClient(
+		klse.WithBaseURL(server.URL),
+		klse.WithRetryPolicy(klse.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}),
+	)
+	if _, err := client.GetMarketInformation(); !errors.Is(err, klse.ErrUpstreamStatus) {
+		t.Errorf("err = %v, want %v", err, klse.ErrUpstreamStatus)
+	}
+	if *attempts != 2 {
+		t.Errorf("attempts = %d, want 2", *attempts)
+	}
+}
+
+func TestRetryNotFound(t *testing.T) {
+	server, attempts := failingServer(1, http.StatusNotFound, "")
+	defer server.Close()
+
+	client := klse.NewClient(
+		klse.WithBaseURL(server.URL),
+		klse.WithRetryPolicy(klse.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}),
+	)
+	if _, err := client.GetCompanyOverview("0000"); !errors.Is(err, klse.ErrNotFound) {
+		t.Errorf("err = %v, want %v", err, klse.ErrNotFound)
+	}
+	if *attempts != 1 {
+		t.Errorf("attempts = %d, want 1", *attempts)
+	}
+}
+
+func TestRetryPost(t *testing.T) {
+	for _, retryPost := range []bool{false, true} {
+		server, attempts := failingServer(1, http.StatusServiceUnavailable, "")
+		client := klse.NewClient(
+			klse.WithBaseURL(server.URL),
+			klse.WithRetryPolicy(klse.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, RetryPost: retryPost}),
+		)
+		quote := client.NewQuoteResultRequest()
+		_, err := quote.GetQuoteResults(quote.WithMinPE(1))
+		server.Close()
+
+		want := int32(1)
+		if retryPost {
+			want = 2
+		}
+		if *attempts != want {
+			t.Errorf("retry post %v: attempts = %d, want %d", retryPost, *attempts, want)
+		}
+		if retryPost && err != nil {
+			t.Errorf("retry post %v: err = %v", retryPost, err)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	server, attempts := failingServer(1, http.StatusTooManyRequests, "1")
+	defer server.Close()
+
+	client := klse.NewClient(
+		klse.WithBaseURL(server.URL),
+		klse.WithRetryPolicy(klse.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}),
+	)
+	start := time.Now()
+	if _, err := client.GetMarketInformation(); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %v, want Retry-After 1s", elapsed)
+	}
+	if *attempts != 2 {
+		t.Errorf("attempts = %d, want 2", *attempts)
+	}
+}
+
+func TestRetryAfterExceedMaxDelay(t *testing.T) {
+	server, attempts := failingServer(1, http.StatusTooManyRequests, "120")
+	defer server.Close()
+
+	client := klse.NewClient(
+		klse.WithBaseURL(server.URL),
+		klse.WithRetryPolicy(klse.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Second}),
+	)
+	_, err := client.GetMarketInformation()
+	var statusErr *klse.StatusError
+	if !errors.As(err, &statusErr) || statusErr.RetryAfter != 120*time.Second {
+		t.Errorf("err = %v, want Retry-After 120s", err)
+	}
+	if *attempts != 1 {
+		t.Errorf("attempts = %d, want 1", *attempts)
+	}
+}
@@ -1,59 +1,77 @@
 package klse
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"regexp"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
 )
 
-// companyOverviewURL is the base url of individual stock page.
-const companyOverviewURL = "https://www.klsescreener.com/v2/stocks/view/"
+// companyOverviewPath is the url path of individual stock page.
+const companyOverviewPath = "/v2/stocks/view/"
 
 // CompanyOverview is the comapany's basic information and reports.
 type CompanyOverview struct {
-	BasicInformation          *CompanyInformation          `json:"basic_information"`
-	Statistic                 *CompanyStatistic            `json:"statistic"`
-	QuaterReports             []*QuarterReport             `json:"quarter_reports"`
-	AnnualReports             []*AnnualReport              `json:"annual_reports"`
-	DividendsReport           []*DividendsReport           `json:"dividends_reports"`
-	CapitalChangesReports     []*CapitalChangesReport      `json:"capital_changes_reports"`
-	WarrantsReport            []*WarrantsReport            `json:"warrants_reports"`
+	BasicInformation          *CompanyInformation           `json:"basic_information"`
+	Statistic                 *CompanyStatistic             `json:"statistic"`
+	QuaterReports             []*QuarterReport              `json:"quarter_reports"`
+	AnnualReports             []*AnnualReport               `json:"annual_reports"`
+	DividendsReport           []*DividendsReport            `json:"dividends_reports"`
+	CapitalChangesReports     []*CapitalChangesReport       `json:"capital_changes_reports"`
+	WarrantsReport            []*WarrantsReport             `json:"warrants_reports"`
 	ShareholdingChangesReport []*ShareholdingChangesReports `json:"shareholding_changes_reports"`
+	Report                    ParseReport                   `json:"parse_report,omitempty"` // fields can not be parsed of all the sections.
+}
+
+// GetCompanyOverview is to get company's information and reports
+// with the default client.
+func GetCompanyOverview(code string) (*CompanyOverview, error) {
+	return DefaultClient.GetCompanyOverview(code)
+}
+
+// GetCompanyOverviewContext is GetCompanyOverview with context
+// using the default client.
+func GetCompanyOverviewContext(ctx context.Context, code string) (*CompanyOverview, error) {
+	return DefaultClient.GetCompanyOverviewContext(ctx, code)
 }
 
 // GetCompanyOverview is to get company's information and reports.
 // Basic Information, Statistic, Quaterly Reports, Annually Reports,
 // Dividends Reports, Capital Changes Reports, Warrants Reports,
 // Shareholding Changes Reports
-func GetCompanyOverview(code string) (*CompanyOverview, error) {
-	company := &CompanyOverview{}
-	url := companyOverviewURL + code
-	resp := newRequest(http.MethodGet, url, nil)
-	defer resp.Body.Close()
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+func (c *Client) GetCompanyOverview(code string) (*CompanyOverview, error) {
+	return c.GetCompanyOverviewContext(context.Background(), code)
+}
+
+// GetCompanyOverviewContext is GetCompanyOverview with context.
+func (c *Client) GetCompanyOverviewContext(ctx context.Context, code string) (*CompanyOverview, error) {
+	url := c.baseURL + companyOverviewPath + code
+	p := c.newParser(ctx, url, Field{Key: "code", Value: code})
+	resp, err := c.newRequest(ctx, http.MethodGet, companyOverviewPath+code, nil)
 	if err != nil {
-		logError.Fatalf("%s, %s : %s", url, resp.Status, err.Error())
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return parseDocument(p, resp.Body, p.parseCompanyOverview)
+}
+
+// parseCompanyOverview is to parse all the sections of individual stock page.
+func (p *parser) parseCompanyOverview(doc *goquery.Document) *CompanyOverview {
+	return &CompanyOverview{
+		BasicInformation:          p.getCompanyInformation(doc),
+		Statistic:                 p.getCompanyStatistic(doc),
+		QuaterReports:             p.getQuarterReport(doc),
+		AnnualReports:             p.getAnnualReport(doc),
+		DividendsReport:           p.getDividendsReport(doc),
+		CapitalChangesReports:     p.getCapitalChangesReport(doc),
+		WarrantsReport:            p.getWarrantsReport(doc),
+		ShareholdingChangesReport: p.getShareholdingChangesReport(doc),
+		Report:                    p.takeReport(),
 	}
-	wg := sync.WaitGroup{}
-	wg.Add(1)
-	go func() {
-		company.BasicInformation = getCompanyInformation(doc)
-		company.Statistic = getCompanyStatistic(doc)
-		company.QuaterReports = getQuarterReport(doc)
-		company.AnnualReports = getAnnualReport(doc)
-		company.DividendsReport = getDividendsReport(doc)
-		company.CapitalChangesReports = getCapitalChangesReport(doc)
-		company.WarrantsReport = getWarrantsReport(doc)
-		company.ShareholdingChangesReport = getShareholdingChangesReport(doc)
-		wg.Done()
-	}()
-	wg.Wait()
-	return company, nil
 }
 
 // CompanyInformation is basic information and statistic
@@ -71,7 +89,7 @@ type CompanyInformation struct {
 
 // GetCompanyGeneralInfo is to get general info eg name, short name
 // code, summary, market...
-func getCompanyInformation(doc *goquery.Document) *CompanyInformation {
+func (p *parser) getCompanyInformation(doc *goquery.Document) *CompanyInformation {
 	company := &CompanyInformation{}
 	page := doc.Find(`#page`).Contents()
 
@@ -95,17 +113,17 @@ func getCompanyInformation(doc *goquery.Document) *CompanyInformation {
 	regexpWebsite := regexp.MustCompile(`http:.*?$`)
 	company.Website = regexpWebsite.FindString(strings.ToLower(company.Summary))
 	price, _ := page.Find(`span#price`).Attr("data-value")
-	company.Price = convertStringToFloat64(price, 3)
+	company.Price = p.float("basic_information.price", price, 3)
 	regexpPriceDiff := regexp.MustCompile(`(\(.*?%\))`)
 	priceDiff := page.Find(`span#priceDiff`).Text()
 	priceDiff = removeAllSpaces(regexpPriceDiff.ReplaceAllString(priceDiff, ""), "")
-	company.PriceDifferent = convertStringToFloat64(priceDiff, 3)
+	company.PriceDifferent = p.float("basic_information.price_different", priceDiff, 3)
 	return company
 }
 
 // CompanyStatistic is the statistic information.
 type CompanyStatistic struct {
-	OHLC            *OHLC   `json:"ohlc"`
+	OHLC            *OHLC   `json:"ohlc"` // without close and Exact, the table has no close.
 	VolumeBuy       int     `json:"volume_buy"`
 	VolumeSell      int     `json:"volume_sell"`
 	PriceBid        float64 `json:"price_bid"`
@@ -130,7 +148,7 @@ type CompanyStatistic struct {
 }
 
 // getCompanyStatistic is to get company's statistic data.
-func getCompanyStatistic(doc *goquery.Document) *CompanyStatistic {
+func (p *parser) getCompanyStatistic(doc *goquery.Document) *CompanyStatistic {
 	report := &CompanyStatistic{}
 	report.OHLC = &OHLC{}
 	regexpFloatDigit := regexp.MustCompile(`[-+]?([0-9]*\.[0-9]+|[0-9]+)`)
@@ -143,67 +161,66 @@ func getCompanyStatistic(doc *goquery.Document) *CompanyStatistic {
 			}
 			key := strings.ToLower(removeAllSpaces(tr.FindNodes(td.Nodes[0]).Text(), ""))
 			value := removeAllSpaces(tr.FindNodes(td.Nodes[1]).Text(), "")
-			report.OHLC.Date = time.Now()
+			report.OHLC.Date = NewTradingDate(p.now).Time()
 			switch key {
 			case "high":
-				report.OHLC.High = convertStringToFloat64(value, 3)
+				report.OHLC.High = p.float("statistic.ohlc.high", value, 3)
 			case "low":
-				report.OHLC.Low = convertStringToFloat64(value, 3)
+				report.OHLC.Low = p.float("statistic.ohlc.low", value, 3)
 			case "open":
-				report.OHLC.Open = convertStringToFloat64(value, 3)
+				report.OHLC.Open = p.float("statistic.ohlc.open", value, 3)
 			case "volume":
-				report.OHLC.Volume = int(convertStringToFloat64(value, 0))
+				report.OHLC.Volume = p.integer("statistic.ohlc.volume", value)
 			case "volume(b/s)":
 				splitVolume := strings.Split(value, "/")
 				if len(splitVolume) == 2 {
-					report.VolumeBuy = int(convertStringToFloat64(splitVolume[0], 0))
-					report.VolumeSell = int(convertStringToFloat64(splitVolume[1], 0))
+					report.VolumeBuy = p.integer("statistic.volume_buy", splitVolume[0])
+					report.VolumeSell = p.integer("statistic.volume_sell", splitVolume[1])
 				}
 			case "pricebid/ask":
 				splitBS := strings.Split(value, "/")
 				if len(splitBS) == 2 {
-					report.PriceBid = convertStringToFloat64(splitBS[0], 3)
-					report.PriceAsk = convertStringToFloat64(splitBS[1], 3)
+					report.PriceBid = p.float("statistic.price_bid", splitBS[0], 3)
+					report.PriceAsk = p.float("statistic.price_ask", splitBS[1], 3)
 				}
 			case "52w":
 				split52Week := strings.Split(value, "-")
 				if len(split52Week) == 2 {
-					report.Low52Week = convertStringToFloat64(split52Week[0], 3)
-					report.High52Week = convertStringToFloat64(split52Week[1], 3)
+					report.Low52Week = p.float("statistic.52_week_low", split52Week[0], 3)
+					report.High52Week = p.float("statistic.52_week_high", split52Week[1], 3)
 				}
 			case "roe":
-				report.ROE = convertStringToFloat64(value, 2)
+				report.ROE = p.float("statistic.roe", value, 2)
 			case "p/e":
-				report.PE = convertStringToFloat64(value, 2)
+				report.PE = p.float("statistic.pe", value, 2)
 			case "eps":
-				report.EPS = convertStringToFloat64(value, 2)
+				report.EPS = p.float("statistic.eps", value, 2)
 			case "dps":
-				report.DPS = convertStringToFloat64(value, 2)
+				report.DPS = p.float("statistic.dps", value, 2)
 			case "dy":
-				dy := convertStringToFloat64(value, 4) / 100
-				report.DY = convertStringToFloat64(fmt.Sprintf("%v", dy), 4)
+				report.DY = roundFloat64(p.float("statistic.dy", value, 4)/100, 4)
 			case "nta":
-				report.NTA = convertStringToFloat64(value, 4)
+				report.NTA = p.float("statistic.nta", value, 4)
 			case "p/b":
-				report.PB = convertStringToFloat64(value, 2)
+				report.PB = p.float("statistic.pb", value, 2)
 			case "rps":
-				report.RPS = convertStringToFloat64(value, 2)
+				report.RPS = p.float("statistic.rps", value, 2)
 			case "psr":
-				report.PSR = convertStringToFloat64(value, 2)
+				report.PSR = p.float("statistic.psr", value, 2)
 			case "marketcap":
-				report.MarketCapital = convertMagnitudeToFloat64(value, 0)
+				report.MarketCapital = p.magnitude("statistic.market_capital", value, 0)
 			case "shares(mil)":
-				report.Shares = convertMagnitudeToFloat64(value+"m", 0)
+				report.Shares = p.magnitude("statistic.shares", value+"m", 0)
 			case "rsi(14)":
 				regexpRSI := regexpFloatDigit.FindString(value)
-				report.RSI14 = convertStringToFloat64(regexpRSI, 1)
+				report.RSI14 = p.float("statistic.rsi_14", regexpRSI, 1)
 			case "stochastic(14)":
 				regexpStochastic := regexpFloatDigit.FindString(value)
-				report.Stochastic14 = convertStringToFloat64(regexpStochastic, 1)
+				report.Stochastic14 = p.float("statistic.stochastic_14", regexpStochastic, 1)
 			case "averagevolume(3m)":
-				report.AverageVolume3M = int(convertStringToFloat64(value, 0))
+				report.AverageVolume3M = p.integer("statistic.average_volume", value)
 			case "relativevolume":
-				report.RelativeVolume = convertStringToFloat64(value, 1)
+				report.RelativeVolume = p.float("statistic.relative_volume", value, 1)
 			}
 		})
 	})
@@ -228,54 +245,58 @@ type QuarterReport struct {
 }
 
 // getQuarterReport is to get company's quarterly reports.
-func getQuarterReport(doc *goquery.Document) []*QuarterReport {
+func (p *parser) getQuarterReport(doc *goquery.Document) []*QuarterReport {
 	reports := []*QuarterReport{}
 	regexpSpaces := regexp.MustCompile(`\s+`)
-	doc.Find(`div#quarter_reports tbody tr`).Each(func(_ int, tr *goquery.Selection) {
+	table := doc.Find(`div#quarter_reports table`)
+	columns := p.tableColumns("quarter_reports", table,
+		"EPS", "DPS", "NTA", "Revenue", "P/L", "Quarter", "Q Date", "Financial Year", "Announced", "ROE", "QoQ", "YoY", "")
+	table.Find(`tbody tr`).Each(func(trIndex int, tr *goquery.Selection) {
 		td := tr.Find(`td`)
 		if len(td.Nodes) < 2 {
 			return
 		}
+		field := elementField("quarter_reports", len(reports))
 		report := &QuarterReport{}
 		td.Each(func(index int, element *goquery.Selection) {
 			text := regexpSpaces.ReplaceAllString(element.Text(), "")
-			switch index {
-			case 0:
-				report.EPS = convertStringToFloat64(text, 2)
-			case 1:
-				report.DPS = convertStringToFloat64(text, 3)
-			case 2:
-				report.NTA = convertStringToFloat64(text, 4)
-			case 3:
-				report.Revenue = convertMagnitudeToFloat64(text, 0)
-			case 4:
-				report.ProfitAndLoss = convertMagnitudeToFloat64(text, 0)
-			case 5:
-				report.Quarter = int(convertStringToFloat64(text, 0))
-			case 6:
-				report.QuarterDate = convertStringToDate(text, "2006-01-02")
-			case 7:
-				report.FinancialYear = convertStringToDate(text, "02Jan,2006")
-			case 8:
-				report.AnnouncedDate = convertStringToDate(text, "2006-01-02")
-			case 9, 10, 11:
+			switch columns.name(index) {
+			case "EPS":
+				report.EPS = p.float(field+"eps", text, 2)
+			case "DPS":
+				report.DPS = p.float(field+"dps", text, 3)
+			case "NTA":
+				report.NTA = p.float(field+"nta", text, 4)
+			case "Revenue":
+				report.Revenue = p.magnitude(field+"revenue", text, 0)
+			case "P/L":
+				report.ProfitAndLoss = p.magnitude(field+"profit_and_loss", text, 0)
+			case "Quarter":
+				report.Quarter = p.integer(field+"quarter", text)
+			case "Q Date":
+				report.QuarterDate = p.date(field+"quarter_date", text, "2006-01-02")
+			case "Financial Year":
+				report.FinancialYear = p.date(field+"financial_year", text, "02Jan,2006")
+			case "Announced":
+				report.AnnouncedDate = p.date(field+"announced_date", text, "2006-01-02")
+			case "ROE", "QoQ", "YoY":
 				text = strings.ReplaceAll(text, "%", "")
-				resultNumber := convertStringToFloat64(text, 1) / 100
-				resultNumber = convertStringToFloat64(fmt.Sprintf("%v", resultNumber), 4)
-				switch index {
-				case 9:
+				name := strings.ToLower(columns.name(index))
+				resultNumber := roundFloat64(p.float(field+name, text, 1)/100, 4)
+				switch columns.name(index) {
+				case "ROE":
 					report.ROE = resultNumber
-				case 10:
+				case "QoQ":
 					report.QoQ = resultNumber
-				case 11:
+				case "YoY":
 					report.YoY = resultNumber
 				}
-			case 12:
+			case "":
 				href, _ := element.Find("a").Attr("href")
 				report.ReportLink = fmt.Sprintf("https://www.klsescreener.com%s", href)
 			}
 		})
-		logInfo.Printf("getting quarter report : %v", report)
+		p.log(LogDebug, "getting quarter report", Field{Key: "row", Value: trIndex}, Field{Key: "data", Value: report})
 		reports = append(reports, report)
 	})
 	return reports
@@ -292,35 +313,40 @@ type AnnualReport struct {
 }
 
 // getAnnualReport is to get company's annually reports.
-func getAnnualReport(doc *goquery.Document) []*AnnualReport {
+func (p *parser) getAnnualReport(doc *goquery.Document) []*AnnualReport {
 	reports := []*AnnualReport{}
-	doc.Find(`#annual tbody tr`).Each(func(_ int, tr *goquery.Selection) {
+	table := doc.Find(`#annual table`)
+	columns := p.tableColumns("annual", table,
+		"Financial Year", "Revenue", "Net Profit", "EPS", "")
+	table.Find(`tbody tr`).Each(func(trIndex int, tr *goquery.Selection) {
 		td := tr.Find(`td`)
 		if len(td.Nodes) < 2 {
 			return
 		}
+		field := elementField("annual_reports", len(reports))
 		report := &AnnualReport{}
 		td.Each(func(i int, element *goquery.Selection) {
 			text := regexpSpaces.ReplaceAllString(element.Text(), "")
-			switch i {
-			case 0:
-				report.FinancialYear = convertStringToDate(text, "02Jan,2006")
-			case 1:
-				report.Revenue = convertStringToFloat64(text, 2)
-			case 2:
-				report.NetProfit = convertStringToFloat64(text, 2)
-			case 3:
-				report.EPS = convertStringToFloat64(text, 2)
-			case 4:
-				href, exist := td.Find(`a`).Attr("href")
+			switch columns.name(i) {
+			case "Financial Year":
+				report.FinancialYear = p.date(field+"financial_year", text, "02Jan,2006")
+			case "Revenue":
+				report.Revenue = p.float(field+"revenue", text, 2)
+			case "Net Profit":
+				report.NetProfit = p.float(field+"net_profit", text, 2)
+			case "EPS":
+				report.EPS = p.float(field+"eps", text, 2)
+			case "":
+				href, exist := element.Find(`a`).Attr("href")
 				if exist {
 					report.ReportLink = fmt.Sprintf("https://www.klsescreener.com%s", href)
 				}
 			}
 		})
-		report.ProfitMargin = convertStringToFloat64(fmt.Sprintf("%v",
-			report.NetProfit/report.Revenue), 4)
-		logInfo.Printf("getting annual report : %v", report)
+		if report.Revenue != 0 {
+			report.ProfitMargin = roundFloat64(report.NetProfit/report.Revenue, 4)
+		}
+		p.log(LogDebug, "getting annual report", Field{Key: "row", Value: trIndex}, Field{Key: "data", Value: report})
 		reports = append(reports, report)
 	})
 	return reports
@@ -328,50 +354,70 @@ func getAnnualReport(doc *goquery.Document) []*AnnualReport {
 
 // DividendsReport is the company's dividend report.
 type DividendsReport struct {
-	AnnouncedDate time.Time `json:"announced_date"`
-	FinancialYear time.Time `json:"financial_year"`
-	Subject       string    `json:"subject"`
-	ExpireDate    time.Time `json:"expired_date"`
-	PaymentDate   time.Time `json:"payment_date"`
-	Amount        float64   `json:"amount"`
-	Indicator     string    `json:"indicator"`
-	ReportLink    string    `json:"report_link"`
+	AnnouncedDate time.Time      `json:"announced_date"`
+	FinancialYear time.Time      `json:"financial_year"`
+	Subject       string         `json:"subject"`
+	ExpireDate    time.Time      `json:"expired_date"`
+	PaymentDate   time.Time      `json:"payment_date"`
+	Amount        float64        `json:"amount"`
+	Indicator     string         `json:"indicator"`
+	ReportLink    string         `json:"report_link"`
+	Exact         *ExactDividend `json:"exact,omitempty"` // with WithExactDecimals only.
+}
+
+// ExactDividend is the exact decimal amount of dividend.
+type ExactDividend struct {
+	Amount Decimal `json:"amount"`
+}
+
+// exactDividend is to parse the exact decimal amount, nil when the exact
+// decimals are not enabled.
+func (p *parser) exactDividend(field, amount string) *ExactDividend {
+	if !p.exact {
+		return nil
+	}
+	return &ExactDividend{Amount: p.decimal(field, amount)}
 }
 
 // getDividendsReport is to get company's dividend reports.
-func getDividendsReport(doc *goquery.Document) []*DividendsReport {
+func (p *parser) getDividendsReport(doc *goquery.Document) []*DividendsReport {
 	reports := []*DividendsReport{}
-	doc.Find(`#dividends table tbody tr`).Each(func(_ int, tr *goquery.Selection) {
+	table := doc.Find(`#dividends table`)
+	columns := p.tableColumns("dividends", table,
+		"Announced", "Financial Year", "Subject", "EX Date", "Payment Date", "Amount", "Indicator", "")
+	table.Find(`tbody tr`).Each(func(trIndex int, tr *goquery.Selection) {
 		td := tr.Find(`td`)
 		if len(td.Nodes) < 7 {
 			return
 		}
+		field := elementField("dividends_reports", len(reports))
 		report := &DividendsReport{}
 		td.Each(func(index int, element *goquery.Selection) {
 			text := regexpSpaces.ReplaceAllString(element.Text(), " ")
 			text = strings.TrimSpace(text)
-			switch index {
-			case 0:
-				report.AnnouncedDate = convertStringToDate(text, "02 Jan 2006")
-			case 1:
-				report.FinancialYear = convertStringToDate(text, "02 Jan 2006")
-			case 2:
+			switch columns.name(index) {
+			case "Announced":
+				report.AnnouncedDate = p.date(field+"announced_date", text, "02 Jan 2006")
+			case "Financial Year":
+				report.FinancialYear = p.date(field+"financial_year", text, "02 Jan 2006")
+			case "Subject":
 				report.Subject = text
-			case 3:
-				report.ExpireDate = convertStringToDate(text, "02 Jan 2006")
-			case 4:
-				report.PaymentDate = convertStringToDate(text, "02 Jan 2006")
-			case 5:
-				report.Amount = convertStringToFloat64(text, 4)
-			case 6:
+			case "EX Date":
+				report.ExpireDate = p.date(field+"expired_date", text, "02 Jan 2006")
+			case "Payment Date":
+				report.PaymentDate = p.date(field+"payment_date", text, "02 Jan 2006")
+			case "Amount":
+				report.Amount = p.float(field+"amount", text, 4)
+				report.Exact = p.exactDividend(field+"amount", text)
+			case "Indicator":
 				report.Indicator = text
-			case 7:
+			case "":
 				href, _ := element.Find(`a`).First().Attr("href")
 				report.ReportLink = klescreenerBaseURL + href
 			}
 		})
 		reports = append(reports, report)
-		logInfo.Printf("getting dividends report : %v\n", report)
+		p.log(LogDebug, "getting dividends report", Field{Key: "row", Value: trIndex}, Field{Key: "data", Value: report})
 	})
 	return reports
 }
@@ -387,35 +433,39 @@ type CapitalChangesReport struct {
 }
 
 // getCapitalChangesReport is to get company's capital changes reports.
-func getCapitalChangesReport(doc *goquery.Document) []*CapitalChangesReport {
+func (p *parser) getCapitalChangesReport(doc *goquery.Document) []*CapitalChangesReport {
 	reports := []*CapitalChangesReport{}
-	doc.Find(`#capital_changes table tbody tr`).Each(func(_ int, tr *goquery.Selection) {
+	table := doc.Find(`#capital_changes table`)
+	columns := p.tableColumns("capital_changes", table,
+		"Announced", "EX Date", "Subject", "Ratio", "Offer Price", "")
+	table.Find(`tbody tr`).Each(func(trIndex int, tr *goquery.Selection) {
 		td := tr.Find(`td`)
 		if len(td.Nodes) < 5 {
 			return
 		}
+		field := elementField("capital_changes_reports", len(reports))
 		report := &CapitalChangesReport{}
 		td.Each(func(index int, element *goquery.Selection) {
 			text := regexpSpaces.ReplaceAllString(element.Text(), " ")
 			text = strings.TrimSpace(text)
-			switch index {
-			case 0:
-				report.AnnouncedDate = convertStringToDate(text, "02 Jan 2006")
-			case 1:
-				report.ExpireDate = convertStringToDate(text, "02 Jan 2006")
-			case 2:
+			switch columns.name(index) {
+			case "Announced":
+				report.AnnouncedDate = p.date(field+"announced_date", text, "02 Jan 2006")
+			case "EX Date":
+				report.ExpireDate = p.date(field+"expired_date", text, "02 Jan 2006")
+			case "Subject":
 				report.Subject = text
-			case 3:
+			case "Ratio":
 				report.Ratio = text
-			case 4:
-				report.Offer = convertStringToFloat64(text, 4)
-			case 5:
+			case "Offer Price":
+				report.Offer = p.float(field+"offer", text, 4)
+			case "":
 				href, _ := element.Find(`a`).First().Attr("href")
 				report.ReportLink = klescreenerBaseURL + href
 			}
 
 		})
-		logInfo.Printf("getting capital changes report : %v\n", report)
+		p.log(LogDebug, "getting capital changes report", Field{Key: "row", Value: trIndex}, Field{Key: "data", Value: report})
 		reports = append(reports, report)
 	})
 	return reports
@@ -436,42 +486,45 @@ type WarrantsReport struct {
 }
 
 // getWarrantsReport is to get company's warrant reports.
-func getWarrantsReport(doc *goquery.Document) []*WarrantsReport {
+func (p *parser) getWarrantsReport(doc *goquery.Document) []*WarrantsReport {
 	reports := []*WarrantsReport{}
-	doc.Find(`#warrants table tbody tr`).Each(func(_ int, tr *goquery.Selection) {
+	table := doc.Find(`#warrants table`)
+	columns := p.tableColumns("warrants", table,
+		"Name", "Price", "Change", "Volume", "Gearing", "Premium", "Premium%", "Maturity")
+	table.Find(`tbody tr`).Each(func(trIndex int, tr *goquery.Selection) {
 		td := tr.Find(`td`)
-		fmt.Println(td.Nodes)
 		if len(td.Nodes) < 8 {
 			return
 		}
+		field := elementField("warrants_reports", len(reports))
 		report := &WarrantsReport{}
 		td.Each(func(index int, element *goquery.Selection) {
 			text := regexpSpaces.ReplaceAllString(element.Text(), " ")
 			text = strings.TrimSpace(text)
-			switch index {
-			case 0:
+			switch columns.name(index) {
+			case "Name":
 				report.Name = text
 				href, _ := element.Find(`a`).First().Attr("href")
 				report.WarrantLink = href
-			case 1:
-				report.Price = convertStringToFloat64(text, 3)
-			case 2:
-				report.Change = convertStringToFloat64(fmt.Sprintf("%v", convertStringToFloat64(text, 4)/100), 4)
-			case 3:
-				report.Volume = int(convertStringToFloat64(text, 0))
-			case 4:
-				report.Gearing = convertStringToFloat64(text, 4)
-			case 5:
-				report.Premium = convertStringToFloat64(text, 3)
-			case 6:
-				report.PremiumPercent = convertStringToFloat64(text, 4) / 100
-			case 7:
-				href, _ := td.Find(`a`).First().Attr("href")
-				report.Maturity = convertStringToDate(text, "2006-01-02")
+			case "Price":
+				report.Price = p.float(field+"price", text, 3)
+			case "Change":
+				report.Change = roundFloat64(p.float(field+"change", text, 4)/100, 4)
+			case "Volume":
+				report.Volume = p.integer(field+"volume", text)
+			case "Gearing":
+				report.Gearing = p.float(field+"gearing", text, 4)
+			case "Premium":
+				report.Premium = p.float(field+"premium", text, 3)
+			case "Premium%":
+				report.PremiumPercent = p.float(field+"premium_percentage", text, 4) / 100
+			case "Maturity":
+				href, _ := element.Find(`a`).First().Attr("href")
+				report.Maturity = p.date(field+"maturity", text, "2006-01-02")
 				report.ReportLink = klescreenerBaseURL + href
 			}
 		})
-		fmt.Println(report)
+		p.log(LogDebug, "getting warrants report", Field{Key: "row", Value: trIndex}, Field{Key: "data", Value: report})
 		reports = append(reports, report)
 	})
 	return reports
@@ -487,31 +540,35 @@ type ShareholdingChangesReports struct {
 }
 
 // getShareholdingChangesReport is to get company's shareholding changes reports.
-func getShareholdingChangesReport(doc *goquery.Document) []*ShareholdingChangesReports {
+func (p *parser) getShareholdingChangesReport(doc *goquery.Document) []*ShareholdingChangesReports {
 	reports := []*ShareholdingChangesReports{}
-	doc.Find(`#shareholding_changes tbody tr`).Each(func(_ int, tr *goquery.Selection) {
+	table := doc.Find(`#shareholding_changes table`)
+	columns := p.tableColumns("shareholding_changes", table,
+		"Announced", "Date Change", "Type", "Shares", "Name")
+	table.Find(`tbody tr`).Each(func(trIndex int, tr *goquery.Selection) {
 		td := tr.Find("td")
 		if len(td.Nodes) < 5 {
 			return
 		}
+		field := elementField("shareholding_changes_reports", len(reports))
 		report := &ShareholdingChangesReports{}
 		td.Each(func(index int, element *goquery.Selection) {
 			text := regexpSpaces.ReplaceAllString(element.Text(), " ")
 			text = strings.TrimSpace(text)
-			switch index {
-			case 0:
-				report.AnnouncedDate = convertStringToDate(text, "02 Jan 2006")
-			case 1:
-				report.DateChange = convertStringToDate(text, "02 Jan 2006")
-			case 2:
+			switch columns.name(index) {
+			case "Announced":
+				report.AnnouncedDate = p.date(field+"announced_date", text, "02 Jan 2006")
+			case "Date Change":
+				report.DateChange = p.date(field+"date_change", text, "02 Jan 2006")
+			case "Type":
 				report.Type = text
-			case 3:
-				report.Shares = int(convertStringToFloat64(text, 0))
-			case 4:
+			case "Shares":
+				report.Shares = p.integer(field+"shares", text)
+			case "Name":
 				report.Name = text
 			}
 		})
-		logInfo.Println(report)
+		p.log(LogDebug, "getting shareholding changes report", Field{Key: "row", Value: trIndex}, Field{Key: "data", Value: report})
 		reports = append(reports, report)
 	})
 	return reports
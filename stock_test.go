@@ -1,8 +1,6 @@
 package klse_test
 
 import (
-	"encoding/json"
-	"fmt"
 	"testing"
 	"time"
 
@@ -10,10 +8,69 @@ import (
 )
 
 func TestGetCompanyOverview(t *testing.T) {
-	timestart := time.Now()
-	companies, _ := klse.GetCompanyOverview("6947")
-	b, _ := json.MarshalIndent(companies, "", "  ")
+	company, err := newFixtureClient(t).GetCompanyOverview("6947")
+	if err != nil {
+		t.Fatal(err)
+	}
 
-	fmt.Println(string(b))
-	fmt.Println(time.Since(timestart))
+	info := company.BasicInformation
+	if info.Name != "DIGI.COM BHD" || info.ShortName != "DIGI" || info.Code != "6947" ||
+		info.Market != "Main Market" || info.Category != "Telecommunications & Media" ||
+		info.Price != 3.65 || info.PriceDifferent != 0.02 || info.Website != "http://www.digi.com.my" {
+		t.Errorf("basic information = %+v", info)
+	}
+
+	if len(company.Report) != 0 {
+		t.Errorf("parse report = %v, want no issue", company.Report)
+	}
+
+	stat := company.Statistic
+	if stat.OHLC.Open != 3.63 || stat.OHLC.High != 3.67 || stat.OHLC.Low != 3.62 || stat.OHLC.Volume != 4567800 {
+		t.Errorf("ohlc = %+v", stat.OHLC)
+	}
+	if stat.VolumeBuy != 1200 || stat.VolumeSell != 3400 || stat.PriceBid != 3.64 || stat.PriceAsk != 3.65 ||
+		stat.Low52Week != 3.1 || stat.High52Week != 4.2 || stat.PE != 28.35 || stat.DY != 0.0356 ||
+		stat.MarketCapital != 28380000000 || stat.Shares != 7775000000 || stat.RSI14 != 55.3 ||
+		stat.Stochastic14 != 61.2 || stat.AverageVolume3M != 6123456 {
+		t.Errorf("statistic = %+v", stat)
+	}
+
+	if len(company.QuaterReports) != 2 {
+		t.Fatalf("quarter reports = %d, want 2", len(company.QuaterReports))
+	}
+	quarter := company.QuaterReports[0]
+	if quarter.EPS != 3.15 || quarter.Revenue != 1620000000 || quarter.ProfitAndLoss != 245100000 || quarter.Quarter != 1 ||
+		!quarter.QuarterDate.Equal(time.Date(2022, 3, 31, 0, 0, 0, 0, klse.MYT)) ||
+		!quarter.FinancialYear.Equal(time.Date(2022, 12, 31, 0, 0, 0, 0, klse.MYT)) ||
+		quarter.QoQ != -0.125 || quarter.ReportLink != "https://www.klsescreener.com/v2/announcements/view/3253811" {
+		t.Errorf("quarter report = %+v", quarter)
+	}
+
+	if len(company.AnnualReports) != 2 || company.AnnualReports[0].ProfitMargin != 0.1644 {
+		t.Errorf("annual reports = %+v", company.AnnualReports)
+	}
+
+	if len(company.DividendsReport) != 2 {
+		t.Fatalf("dividends reports = %d, want 2", len(company.DividendsReport))
+	}
+	dividend := company.DividendsReport[0]
+	if dividend.Amount != 0.033 || !dividend.ExpireDate.Equal(time.Date(2022, 6, 9, 0, 0, 0, 0, klse.MYT)) ||
+		dividend.Subject != "First Interim Dividend" {
+		t.Errorf("dividends report = %+v", dividend)
+	}
+
+	if len(company.CapitalChangesReports) != 2 || company.CapitalChangesReports[0].Ratio != "1 : 2" ||
+		company.CapitalChangesReports[1].Subject != "Share Split" {
+		t.Errorf("capital changes reports = %+v", company.CapitalChangesReports)
+	}
+
+	if len(company.WarrantsReport) != 1 || company.WarrantsReport[0].Name != "DIGI-CG" ||
+		company.WarrantsReport[0].Change != 0.125 || company.WarrantsReport[0].Volume != 1500000 {
+		t.Errorf("warrants reports = %+v", company.WarrantsReport)
+	}
+
+	if len(company.ShareholdingChangesReport) != 2 || company.ShareholdingChangesReport[1].Type != "Acquired" ||
+		company.ShareholdingChangesReport[1].Shares != 3000000 {
+		t.Errorf("shareholding changes reports = %+v", company.ShareholdingChangesReport)
+	}
 }
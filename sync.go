@@ -0,0 +1,169 @@
+package klse
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+)
+
+// Store is the storage of the daily bars of every stock code.
+type Store interface {
+	// LastBar is to get the latest stored bar of the code, nil when no bar is stored.
+	LastBar(ctx context.Context, code string) (*OHLC, error)
+	// UpsertBars is to insert or replace the bars of the code by trading date.
+	UpsertBars(ctx context.Context, code string, bars []*OHLC) error
+}
+
+// SyncStatus is the result of the sync of a stock code.
+type SyncStatus struct {
+	Code    string
+	Last    TradingDate // latest stored trading date before the sync, zero when none.
+	Period  Period      // period of the historical data requested.
+	Fetched int         // number of the bars fetched.
+	Added   int         // number of the new bars upserted.
+	Err     error
+}
+
+// UpToDate is to check the sync has no error and no new bar.
+func (s *SyncStatus) UpToDate() bool {
+	return s.Err == nil && s.Added == 0
+}
+
+// Syncer is to keep the daily bars of the store up to date, only the bars
+// after the latest stored bar of each code are fetched and upserted.
+type Syncer struct {
+	client *Client
+	store  Store
+	// InitialPeriod is the period fetched for the code without stored bar,
+	// it is Period10Y by default.
+	InitialPeriod Period
+}
+
+// NewSyncer is to initialise the syncer of the store, the default client is
+// used when client is nil.
+func NewSyncer(client *Client, store Store) *Syncer {
+	if client == nil {
+		client = DefaultClient
+	}
+	return &Syncer{client: client, store: store, InitialPeriod: Period10Y}
+}
+
+// Sync is to sync the codes one by one, the status of every code is
+// returned in the same order. The codes not synced when the context is
+// cancelled have the context error.
+func (s *Syncer) Sync(ctx context.Context, codes ...string) []*SyncStatus {
+	statuses := make([]*SyncStatus, 0, len(codes))
+	for _, code := range codes {
+		statuses = append(statuses, s.SyncCode(ctx, code))
+	}
+	return statuses
+}
+
+// SyncCode is to fetch the shortest period covering the gap since the
+// latest stored bar of the code and upsert the new bars.
+func (s *Syncer) SyncCode(ctx context.Context, code string) *SyncStatus {
+	status := &SyncStatus{Code: code, Period: s.InitialPeriod}
+	if status.Err = ctx.Err(); status.Err != nil {
+		return status
+	}
+	last, err := s.store.LastBar(ctx, code)
+	if err != nil {
+		status.Err = err
+		return status
+	}
+	if last != nil {
+		status.Last = last.TradingDate()
+		status.Period = ShortestPeriod(status.Last, NewTradingDate(s.client.clock()))
+	}
+	// the bars are upserted when some rows are skipped, Err is *SkippedRowsError.
+	bars, err := s.client.GetStockHistoricalDataContext(ctx, code, WithPeriod(status.Period))
+	if status.Err = err; err != nil && !errors.Is(err, ErrSkippedRows) {
+		return status
+	}
+	status.Fetched = len(bars)
+	bars = newBars(bars, status.Last)
+	if len(bars) == 0 {
+		return status
+	}
+	if err := s.store.UpsertBars(ctx, code, bars); err != nil {
+		status.Err = err
+		return status
+	}
+	status.Added = len(bars)
+	s.client.logger.Log(LogInfo, "synced historical data", Field{Key: "code", Value: code},
+		Field{Key: "period", Value: status.Period}, Field{Key: "added", Value: status.Added})
+	return status
+}
+
+// newBars is to de-duplicate the bars by trading date, the later one is
+// kept, and return the bars after the last date sorted by date.
+func newBars(bars []*OHLC, last TradingDate) []*OHLC {
+	byDate := map[TradingDate]*OHLC{}
+	for _, bar := range bars {
+		if date := bar.TradingDate(); last.IsZero() || date.After(last) {
+			byDate[date] = bar
+		}
+	}
+	unique := make([]*OHLC, 0, len(byDate))
+	for _, bar := range byDate {
+		unique = append(unique, bar)
+	}
+	sort.Slice(unique, func(i, j int) bool {
+		return unique[i].Date.Before(unique[j].Date)
+	})
+	return unique
+}
+
+// MemoryStore is the Store in memory, safe for concurrent use.
+type MemoryStore struct {
+	mutex sync.Mutex
+	bars  map[string]map[TradingDate]*OHLC
+}
+
+// NewMemoryStore is to initialise the empty memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{bars: map[string]map[TradingDate]*OHLC{}}
+}
+
+// LastBar is to implement Store.
+func (m *MemoryStore) LastBar(ctx context.Context, code string) (*OHLC, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	var last *OHLC
+	for _, bar := range m.bars[code] {
+		if last == nil || bar.Date.After(last.Date) {
+			last = bar
+		}
+	}
+	return last, nil
+}
+
+// UpsertBars is to implement Store.
+func (m *MemoryStore) UpsertBars(ctx context.Context, code string, bars []*OHLC) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.bars[code] == nil {
+		m.bars[code] = map[TradingDate]*OHLC{}
+	}
+	for _, bar := range bars {
+		m.bars[code][bar.TradingDate()] = bar
+	}
+	return nil
+}
+
+// Bars is to get the stored bars of the code sorted by date.
+func (m *MemoryStore) Bars(code string) []*OHLC {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return newBars(mapValues(m.bars[code]), TradingDate{})
+}
+
+// mapValues is to get the values of the map in any order.
+func mapValues[K comparable, V any](m map[K]V) []V {
+	values := make([]V, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return values
+}
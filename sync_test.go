@@ -0,0 +1,108 @@
+package klse_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	klse "github.com/kokweikhong/klsescreener-scraper"
+)
+
+// failingStore is the store which can't be read.
+type failingStore struct{ *klse.MemoryStore }
+
+func (failingStore) LastBar(ctx context.Context, code string) (*klse.OHLC, error) {
+	return nil, errors.New("store is down")
+}
+
+func TestSyncer(t *testing.T) {
+	server := chartPageServer(periodRows)
+	defer server.Close()
+	client := klse.NewClient(klse.WithBaseURL(server.URL), klse.WithChartRateLimit(0, 0), klse.WithClock(fixtureClock))
+
+	store := klse.NewMemoryStore()
+	store.UpsertBars(context.Background(), "7251", []*klse.OHLC{
+		{Date: time.Date(2022, 7, 1, 0, 0, 0, 0, klse.MYT), Close: 9},
+	})
+	syncer := klse.NewSyncer(client, store)
+	statuses := syncer.Sync(context.Background(), "7251", "0001")
+
+	incremental, initial := statuses[0], statuses[1]
+	if incremental.Err != nil || incremental.Period != klse.Period1M || incremental.Fetched != 2 || incremental.Added != 1 ||
+		incremental.Last != (klse.TradingDate{Year: 2022, Month: time.July, Day: 1}) {
+		t.Errorf("incremental = %+v", incremental)
+	}
+	if bars := store.Bars("7251"); len(bars) != 2 || bars[0].Close != 9 || bars[1].TradingDate().String() != "2022-07-08" {
+		t.Errorf("7251 bars = %d", len(bars))
+	}
+	if initial.Err != nil || initial.Period != klse.Period10Y || initial.Added != 4 || !initial.Last.IsZero() {
+		t.Errorf("initial = %+v", initial)
+	}
+	if paths := server.Paths(); paths[0] != "/v2/stocks/chart/7251/embedded/1m" || paths[1] != "/v2/stocks/chart/0001/embedded/10y" {
+		t.Errorf("paths = %v", paths)
+	}
+
+	status := syncer.SyncCode(context.Background(), "7251")
+	if !status.UpToDate() || status.Period != klse.Period1M {
+		t.Errorf("second sync = %+v", status)
+	}
+}
+
+func TestSyncerDuplicates(t *testing.T) {
+	server := chartPageServer(`[1656604800000,1,2,0.5,1.5,100],[1656604800000,1,2,0.5,1.6,200],`)
+	defer server.Close()
+	client := klse.NewClient(klse.WithBaseURL(server.URL), klse.WithChartRateLimit(0, 0), klse.WithClock(fixtureClock))
+
+	store := klse.NewMemoryStore()
+	status := klse.NewSyncer(client, store).SyncCode(context.Background(), "7251")
+	if status.Err != nil || status.Fetched != 2 || status.Added != 1 {
+		t.Errorf("status = %+v", status)
+	}
+	if bars := store.Bars("7251"); len(bars) != 1 || bars[0].Close != 1.6 {
+		t.Errorf("bars = %+v", bars)
+	}
+}
+
+func TestSyncerErrors(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	client := klse.NewClient(klse.WithBaseURL(server.URL), klse.WithChartRateLimit(0, 0), klse.WithRetryPolicy(klse.RetryPolicy{}))
+
+	statuses := klse.NewSyncer(client, klse.NewMemoryStore()).Sync(context.Background(), "9999")
+	if !errors.Is(statuses[0].Err, klse.ErrNotFound) || statuses[0].UpToDate() {
+		t.Errorf("not found = %+v", statuses[0])
+	}
+
+	status := klse.NewSyncer(client, failingStore{klse.NewMemoryStore()}).SyncCode(context.Background(), "7251")
+	if status.Err == nil || status.Err.Error() != "store is down" {
+		t.Errorf("store error = %v", status.Err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	statuses = klse.NewSyncer(client, klse.NewMemoryStore()).Sync(ctx, "7251", "0001")
+	for _, status := range statuses {
+		if !errors.Is(status.Err, context.Canceled) {
+			t.Errorf("%s err = %v", status.Code, status.Err)
+		}
+	}
+}
+
+func TestSyncerNullClose(t *testing.T) {
+	server := chartPageServer(`[1656288000000,1,2,0.5,1.5,100],[1656374400000,1.5,2,1,null,200],`)
+	defer server.Close()
+	client := klse.NewClient(klse.WithBaseURL(server.URL), klse.WithChartRateLimit(0, 0), klse.WithClock(fixtureClock))
+
+	store := klse.NewMemoryStore()
+	status := klse.NewSyncer(client, store).SyncCode(context.Background(), "7251")
+	if !errors.Is(status.Err, klse.ErrSkippedRows) || status.Added != 1 {
+		t.Errorf("status = %+v", status)
+	}
+	// the bar without close is not stored, so it is fetched again by the next sync.
+	if bars := store.Bars("7251"); len(bars) != 1 || bars[0].Close != 1.5 {
+		t.Errorf("bars = %+v", bars)
+	}
+}
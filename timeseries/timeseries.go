@@ -0,0 +1,211 @@
+// Package timeseries is to resample the daily historical data of klse to
+// weekly, monthly, quarterly and yearly bars.
+package timeseries
+
+import (
+	"sort"
+	"time"
+
+	klse "github.com/kokweikhong/klsescreener-scraper"
+)
+
+// Interval is the length of the resampled bar.
+type Interval int
+
+const (
+	Daily Interval = iota
+	Weekly
+	Monthly
+	Quarterly
+	Yearly
+)
+
+// String is the name of the interval, eg "weekly".
+func (i Interval) String() string {
+	switch i {
+	case Daily:
+		return "daily"
+	case Weekly:
+		return "weekly"
+	case Monthly:
+		return "monthly"
+	case Quarterly:
+		return "quarterly"
+	case Yearly:
+		return "yearly"
+	}
+	return "unknown"
+}
+
+// PeriodStart is the first calendar date of the interval which the date
+// belongs to, the week of Bursa starts on Monday.
+func PeriodStart(interval Interval, date klse.TradingDate) klse.TradingDate {
+	switch interval {
+	case Weekly:
+		weekday := int(date.Time().Weekday()+6) % 7 // days since Monday.
+		return date.AddDays(-weekday)
+	case Monthly:
+		return klse.TradingDate{Year: date.Year, Month: date.Month, Day: 1}
+	case Quarterly:
+		return klse.TradingDate{Year: date.Year, Month: (date.Month-1)/3*3 + 1, Day: 1}
+	case Yearly:
+		return klse.TradingDate{Year: date.Year, Month: time.January, Day: 1}
+	}
+	return date
+}
+
+// Series is the daily bars of a stock or bursa index sorted by date.
+type Series []*klse.OHLC
+
+// NewSeries is to initialise the series of the bars sorted by date,
+// the bars are not copied.
+func NewSeries(bars []*klse.OHLC) Series {
+	series := make(Series, len(bars))
+	copy(series, bars)
+	sort.SliceStable(series, func(i, j int) bool {
+		return series[i].Date.Before(series[j].Date)
+	})
+	return series
+}
+
+// Resample is to aggregate the bars of every interval, the open is the first
+// open, the high is the highest high, the low is the lowest low, the close
+// is the last close and the volume is the sum. The date of the bar is the
+// last trading day of the interval, eg Thursday when Friday is a holiday.
+// The exact prices are aggregated only when every bar of the interval has them.
+func (s Series) Resample(interval Interval) Series {
+	resampled := Series{}
+	var start klse.TradingDate
+	for _, bar := range s {
+		barStart := PeriodStart(interval, bar.TradingDate())
+		if len(resampled) == 0 || barStart != start {
+			start = barStart
+			resampled = append(resampled, copyOHLC(bar))
+			continue
+		}
+		mergeOHLC(resampled[len(resampled)-1], bar)
+	}
+	return resampled
+}
+
+// copyOHLC is to copy the bar and its exact prices.
+func copyOHLC(bar *klse.OHLC) *klse.OHLC {
+	c := *bar
+	if bar.Exact != nil {
+		exact := *bar.Exact
+		c.Exact = &exact
+	}
+	return &c
+}
+
+// mergeOHLC is to aggregate the next bar into the bar of the interval.
+func mergeOHLC(bar, next *klse.OHLC) {
+	bar.Date = next.Date
+	if next.High > bar.High {
+		bar.High = next.High
+	}
+	if next.Low < bar.Low {
+		bar.Low = next.Low
+	}
+	bar.Close = next.Close
+	bar.Volume += next.Volume
+	if bar.Exact == nil || next.Exact == nil {
+		bar.Exact = nil
+		return
+	}
+	if next.Exact.High.Cmp(bar.Exact.High) > 0 {
+		bar.Exact.High = next.Exact.High
+	}
+	if next.Exact.Low.Cmp(bar.Exact.Low) < 0 {
+		bar.Exact.Low = next.Exact.Low
+	}
+	bar.Exact.Close = next.Exact.Close
+}
+
+// Dates is the dates of the bars.
+func (s Series) Dates() []time.Time {
+	dates := make([]time.Time, len(s))
+	for i, bar := range s {
+		dates[i] = bar.Date
+	}
+	return dates
+}
+
+// Closes is the close prices of the bars.
+func (s Series) Closes() []float64 {
+	closes := make([]float64, len(s))
+	for i, bar := range s {
+		closes[i] = bar.Close
+	}
+	return closes
+}
+
+// Volumes is the volume of the bars.
+func (s Series) Volumes() []int {
+	volumes := make([]int, len(s))
+	for i, bar := range s {
+		volumes[i] = bar.Volume
+	}
+	return volumes
+}
+
+// MarketSeries is the daily close and volume of a market index sorted by date.
+type MarketSeries []*klse.MarketHistoricalData
+
+// NewMarketSeries is to initialise the series of the market index data
+// sorted by date, the data are not copied.
+func NewMarketSeries(data []*klse.MarketHistoricalData) MarketSeries {
+	series := make(MarketSeries, len(data))
+	copy(series, data)
+	sort.SliceStable(series, func(i, j int) bool {
+		return series[i].Date.Before(series[j].Date)
+	})
+	return series
+}
+
+// Resample is to aggregate the data of every interval, the close is the last
+// close and the volume is the sum. The date is the last trading day of the interval.
+func (s MarketSeries) Resample(interval Interval) MarketSeries {
+	resampled := MarketSeries{}
+	var start klse.TradingDate
+	for _, data := range s {
+		dataStart := PeriodStart(interval, data.TradingDate())
+		if len(resampled) == 0 || dataStart != start {
+			start = dataStart
+			c := *data
+			resampled = append(resampled, &c)
+			continue
+		}
+		last := resampled[len(resampled)-1]
+		last.Date = data.Date
+		last.Close = data.Close
+		last.Volume += data.Volume
+	}
+	return resampled
+}
+
+// Closes is the close of the data.
+func (s MarketSeries) Closes() []float64 {
+	closes := make([]float64, len(s))
+	for i, data := range s {
+		closes[i] = data.Close
+	}
+	return closes
+}
+
+// Series is to convert the market index data to the bars, the open, high and
+// low are the close for the indicators which need OHLC.
+func (s MarketSeries) Series() Series {
+	series := make(Series, len(s))
+	for i, data := range s {
+		series[i] = &klse.OHLC{
+			Date:   data.Date,
+			Open:   data.Close,
+			High:   data.Close,
+			Low:    data.Close,
+			Close:  data.Close,
+			Volume: data.Volume,
+		}
+	}
+	return series
+}
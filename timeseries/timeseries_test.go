@@ -0,0 +1,137 @@
+package timeseries_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	klse "github.com/kokweikhong/klsescreener-scraper"
+	"github.com/kokweikhong/klsescreener-scraper/internal/fixture"
+	"github.com/kokweikhong/klsescreener-scraper/keys"
+	"github.com/kokweikhong/klsescreener-scraper/timeseries"
+)
+
+// newFixtureClient is to initialise the client replaying the fixtures of klse,
+// or recording them with -record.
+func newFixtureClient() *klse.Client {
+	return fixture.NewClient("../testdata/fixtures", klse.WithExactDecimals())
+}
+
+// bar is the daily bar of the date in MYT.
+func bar(year int, month time.Month, day int, open, high, low, close float64, volume int) *klse.OHLC {
+	return &klse.OHLC{Date: time.Date(year, month, day, 0, 0, 0, 0, klse.MYT),
+		Open: open, High: high, Low: low, Close: close, Volume: volume}
+}
+
+func TestPeriodStart(t *testing.T) {
+	date := klse.TradingDate{Year: 2022, Month: time.August, Day: 18} // Thursday
+	tests := []struct {
+		interval timeseries.Interval
+		want     string
+	}{
+		{timeseries.Daily, "2022-08-18"},
+		{timeseries.Weekly, "2022-08-15"},
+		{timeseries.Monthly, "2022-08-01"},
+		{timeseries.Quarterly, "2022-07-01"},
+		{timeseries.Yearly, "2022-01-01"},
+	}
+	for _, tt := range tests {
+		if got := timeseries.PeriodStart(tt.interval, date).String(); got != tt.want {
+			t.Errorf("PeriodStart(%v) = %s, want %s", tt.interval, got, tt.want)
+		}
+	}
+	sunday := klse.TradingDate{Year: 2022, Month: time.August, Day: 21}
+	if got := timeseries.PeriodStart(timeseries.Weekly, sunday).String(); got != "2022-08-15" {
+		t.Errorf("week of Sunday = %s", got)
+	}
+}
+
+func TestResampleWeekly(t *testing.T) {
+	// Friday 2022-09-16 is Malaysia Day, the week ends on Thursday.
+	series := timeseries.NewSeries([]*klse.OHLC{
+		bar(2022, 9, 19, 1.2, 1.3, 1.1, 1.25, 50),
+		bar(2022, 9, 12, 1.0, 1.1, 0.9, 1.05, 100),
+		bar(2022, 9, 13, 1.05, 1.2, 1.0, 1.1, 200),
+		bar(2022, 9, 15, 1.1, 1.15, 0.85, 1.15, 300),
+	})
+	weekly := series.Resample(timeseries.Weekly)
+	if len(weekly) != 2 {
+		t.Fatalf("weekly = %d, want 2", len(weekly))
+	}
+	week := weekly[0]
+	if week.TradingDate().String() != "2022-09-15" || week.Open != 1.0 || week.High != 1.2 || week.Low != 0.85 ||
+		week.Close != 1.15 || week.Volume != 600 {
+		t.Errorf("week = %+v", week)
+	}
+	if series[0].Volume != 100 || series[0].Close != 1.05 {
+		t.Errorf("series is changed, first = %+v", series[0])
+	}
+	if closes := weekly.Closes(); closes[1] != 1.25 {
+		t.Errorf("closes = %v", closes)
+	}
+}
+
+func TestResampleFixture(t *testing.T) {
+	data, err := newFixtureClient().GetStockHistoricalData("7251")
+	if err != nil {
+		t.Fatal(err)
+	}
+	series := timeseries.NewSeries(data)
+	monthly := series.Resample(timeseries.Monthly)
+	volume := 0
+	for _, bar := range monthly {
+		volume += bar.Volume
+	}
+	for _, v := range series.Volumes() {
+		volume -= v
+	}
+	if volume != 0 {
+		t.Errorf("volume difference = %d", volume)
+	}
+	if len(monthly) != 3 || monthly[0].Open != series[0].Open || monthly[2].Close != series[len(series)-1].Close {
+		t.Errorf("monthly = %d", len(monthly))
+	}
+	if monthly[0].Exact == nil || monthly[0].Exact.Open.Float64() != monthly[0].Open ||
+		monthly[0].Exact.High.Float64() != monthly[0].High || monthly[0].Exact.Close.Float64() != monthly[0].Close {
+		t.Errorf("exact = %+v, bar = %+v", monthly[0].Exact, monthly[0])
+	}
+	if quarterly := series.Resample(timeseries.Quarterly); len(quarterly) != 2 {
+		t.Errorf("quarterly = %d, want 2", len(quarterly))
+	}
+}
+
+func TestResampleNullClose(t *testing.T) {
+	// the null close of 2022-07-07 and the null low of 2022-07-08 are skipped.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<script>var data = [[1657036800000,1,1.2,0.9,1.1,100],[1657123200000,1.1,1.3,1,null,200],` +
+			`[1657209600000,1.1,1.4,null,1.2,300],];</script>`))
+	}))
+	defer server.Close()
+	client := klse.NewClient(klse.WithBaseURL(server.URL), klse.WithChartRateLimit(0, 0))
+
+	data, err := client.GetStockHistoricalData("7251")
+	if !errors.Is(err, klse.ErrSkippedRows) {
+		t.Fatalf("err = %v, want ErrSkippedRows", err)
+	}
+	weekly := timeseries.NewSeries(data).Resample(timeseries.Weekly)
+	if len(weekly) != 1 || weekly[0].Close != 1.1 || weekly[0].Low != 0.9 || weekly[0].Volume != 100 {
+		t.Errorf("weekly = %+v, want the bars with null prices skipped", weekly)
+	}
+}
+
+func TestMarketSeriesResample(t *testing.T) {
+	data, err := newFixtureClient().GetMarketIndexHistoricalData(keys.GOLD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	series := timeseries.NewMarketSeries(data)
+	yearly := series.Resample(timeseries.Yearly)
+	if len(yearly) != 1 || yearly[0].Close != series[len(series)-1].Close || !yearly[0].Date.Equal(series[len(series)-1].Date) {
+		t.Errorf("yearly = %+v", yearly)
+	}
+	if bars := series.Series(); len(bars) != len(series) || bars[0].High != series[0].Close {
+		t.Errorf("bars = %d", len(bars))
+	}
+}
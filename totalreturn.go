@@ -0,0 +1,88 @@
+package klse
+
+import (
+	"context"
+	"time"
+)
+
+// TotalReturn is the price-only and total return index of the trading day,
+// both indexes are 100 on the first day.
+type TotalReturn struct {
+	Date             time.Time `json:"date"`
+	Close            float64   `json:"close"`              // close adjusted for splits and bonus issues.
+	Dividend         float64   `json:"dividend"`           // adjusted dividend per share reinvested on the day.
+	PriceIndex       float64   `json:"price_index"`        // close / first close x 100.
+	TotalReturnIndex float64   `json:"total_return_index"` // with the dividends reinvested at the close.
+}
+
+// TradingDate is the trading date of the index.
+func (r *TotalReturn) TradingDate() TradingDate {
+	return NewTradingDate(r.Date)
+}
+
+// NewTotalReturns is to calculate the total return of the bars sorted by date,
+// the dividends are reinvested at the close of the first trading day on or
+// after the ex date. The bars and dividends are adjusted for the adjustments,
+// the dividends without ex date or outside the bars are ignored.
+func NewTotalReturns(bars []*OHLC, dividends []*DividendsReport, adjustments []*Adjustment) []*TotalReturn {
+	returns := make([]*TotalReturn, 0, len(bars))
+	bars = AdjustOHLC(bars, adjustments)
+	for i, bar := range bars {
+		r := &TotalReturn{Date: bar.Date, Close: bar.Close, PriceIndex: 100, TotalReturnIndex: 100}
+		if i == 0 {
+			returns = append(returns, r)
+			continue
+		}
+		previous, first := bars[i-1], bars[0]
+		date, previousDate := bar.TradingDate(), previous.TradingDate()
+		for _, dividend := range dividends {
+			exDate := NewTradingDate(dividend.ExpireDate)
+			if dividend.ExpireDate.IsZero() || !exDate.After(previousDate) || exDate.After(date) {
+				continue
+			}
+			before, after := adjustmentShares(date, adjustments)
+			r.Dividend += dividend.Amount * before / after
+		}
+		last := returns[i-1]
+		r.TotalReturnIndex = last.TotalReturnIndex
+		if previous.Close != 0 {
+			r.TotalReturnIndex = last.TotalReturnIndex * (bar.Close + r.Dividend) / previous.Close
+		}
+		if first.Close != 0 {
+			r.PriceIndex = bar.Close / first.Close * 100
+		}
+		returns = append(returns, r)
+	}
+	return returns
+}
+
+// TotalReturnSeries is to get the price-only and total return index of the
+// stock with the default client.
+func TotalReturnSeries(code string, opts ...HistoricalOption) ([]*TotalReturn, error) {
+	return DefaultClient.TotalReturnSeries(code, opts...)
+}
+
+// TotalReturnSeriesContext is TotalReturnSeries with context using the default client.
+func TotalReturnSeriesContext(ctx context.Context, code string, opts ...HistoricalOption) ([]*TotalReturn, error) {
+	return DefaultClient.TotalReturnSeriesContext(ctx, code, opts...)
+}
+
+// TotalReturnSeries is to get the price-only and total return index of the
+// stock, the prices are adjusted for the splits and bonus issues and the
+// dividends of the company overview are reinvested on the ex date.
+func (c *Client) TotalReturnSeries(code string, opts ...HistoricalOption) ([]*TotalReturn, error) {
+	return c.TotalReturnSeriesContext(context.Background(), code, opts...)
+}
+
+// TotalReturnSeriesContext is TotalReturnSeries with context.
+func (c *Client) TotalReturnSeriesContext(ctx context.Context, code string, opts ...HistoricalOption) ([]*TotalReturn, error) {
+	company, adjustments, err := c.companyAdjustmentsContext(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	bars, err := c.GetStockHistoricalDataContext(ctx, code, opts...)
+	if bars == nil {
+		return nil, err
+	}
+	return NewTotalReturns(bars, company.DividendsReport, adjustments), err
+}
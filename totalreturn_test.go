@@ -0,0 +1,71 @@
+package klse_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	klse "github.com/kokweikhong/klsescreener-scraper"
+)
+
+// dividendRow is the row of dividends table.
+func dividendRow(exDate, amount string) string {
+	return `<tr><td>01 Jun 2022</td><td>31 Dec 2022</td><td>Interim Dividend</td><td>` + exDate +
+		`</td><td>15 Jul 2022</td><td>` + amount + `</td><td>-</td><td><a href="/v2/announcements/view/2">View</a></td></tr>`
+}
+
+func TestTotalReturnSeries(t *testing.T) {
+	chart := `[1654012800000,1,1,1,1,100],` + // 2022-06-01
+		`[1656604800000,1,1,1,1,100],` + // 2022-07-01
+		`[1657209600000,0.5,0.5,0.5,0.5,200],` // 2022-07-08
+	server := stockServer(chart,
+		[]string{capitalChangeRow("08 Jul 2022", "Share Split", "2 : 1")},
+		[]string{
+			dividendRow("30 Jun 2022", "0.1"), // not a trading day, reinvested on 2022-07-01.
+			dividendRow("15 Jan 2022", "0.2"), // before the first day.
+			dividendRow("-", "0.3"),
+		})
+	defer server.Close()
+	client := klse.NewClient(klse.WithBaseURL(server.URL), klse.WithChartRateLimit(0, 0))
+
+	returns, err := client.TotalReturnSeries("7251")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []klse.TotalReturn{
+		{Close: 0.5, Dividend: 0, PriceIndex: 100, TotalReturnIndex: 100},
+		{Close: 0.5, Dividend: 0.05, PriceIndex: 100, TotalReturnIndex: 110},
+		{Close: 0.5, Dividend: 0, PriceIndex: 100, TotalReturnIndex: 110},
+	}
+	if len(returns) != len(want) {
+		t.Fatalf("returns = %d, want %d", len(returns), len(want))
+	}
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+	for i, w := range want {
+		r := returns[i]
+		if !near(r.Close, w.Close) || !near(r.Dividend, w.Dividend) || !near(r.PriceIndex, w.PriceIndex) ||
+			!near(r.TotalReturnIndex, w.TotalReturnIndex) {
+			t.Errorf("returns[%d] = %+v, want %+v", i, r, w)
+		}
+	}
+	if returns[1].TradingDate().String() != "2022-07-01" {
+		t.Errorf("returns[1] date = %v", returns[1].TradingDate())
+	}
+}
+
+func TestTotalReturnSeriesNullClose(t *testing.T) {
+	chart := `[1654012800000,1,1,1,1,100],` + // 2022-06-01
+		`[1656604800000,1,1,1,null,100],` + // 2022-07-01
+		`[1657209600000,1.1,1.1,1.1,1.1,200],` // 2022-07-08
+	server := stockServer(chart, nil, nil)
+	defer server.Close()
+	client := klse.NewClient(klse.WithBaseURL(server.URL), klse.WithChartRateLimit(0, 0))
+
+	returns, err := client.TotalReturnSeries("7251")
+	if !errors.Is(err, klse.ErrSkippedRows) {
+		t.Fatalf("err = %v, want ErrSkippedRows", err)
+	}
+	if len(returns) != 2 || math.Abs(returns[1].TotalReturnIndex-110) > 1e-9 {
+		t.Errorf("returns = %+v, want the null close skipped", returns)
+	}
+}
@@ -0,0 +1,120 @@
+package klse
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// regexpFixtureName is to find the characters not allowed in fixture file name.
+var regexpFixtureName = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// fixtureFileName is the file name of the request's fixture in the fixtures directory.
+// eg "GET_v2_stocks_view_0001.http", POST request has the hash of the form body
+// eg "POST_v2_screener_quote_results_1a2b3c4d.http".
+func fixtureFileName(method, escapedPath, rawQuery string, body []byte) string {
+	name := method + "_" + strings.Trim(escapedPath, "/")
+	if rawQuery != "" {
+		name += "_" + rawQuery
+	}
+	if len(body) > 0 {
+		hash := sha256.Sum256(body)
+		name += "_" + hex.EncodeToString(hash[:4])
+	}
+	return regexpFixtureName.ReplaceAllString(name, "_") + ".http"
+}
+
+// fixtureName is the fixture file name of the request, the request body is
+// read and replaced.
+func fixtureName(req *http.Request) (string, error) {
+	var body []byte
+	if req.Body != nil {
+		b, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return "", err
+		}
+		req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(b))
+		body = b
+	}
+	return fixtureFileName(req.Method, req.URL.EscapedPath(), req.URL.RawQuery, body), nil
+}
+
+// recordingTransport is the http.RoundTripper saves the responses as fixtures.
+type recordingTransport struct {
+	dir  string
+	next http.RoundTripper
+}
+
+// NewRecordingTransport is to initialise http.RoundTripper which sends the
+// requests with next and saves every response to the fixtures directory,
+// next is http.DefaultTransport if nil. Use it with WithHTTPClient.
+// example : transport := NewRecordingTransport("testdata/fixtures", nil)
+// client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+func NewRecordingTransport(dir string, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &recordingTransport{dir: dir, next: next}
+}
+
+// RoundTrip is to implement http.RoundTripper interface.
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	name, err := fixtureName(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	// DumpResponse reads and replaces the response body.
+	b, err := httputil.DumpResponse(resp, true)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if err := os.MkdirAll(t.dir, 0o755); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(t.dir, name), b, 0o644); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp, nil
+}
+
+// replayTransport is the http.RoundTripper serves the saved fixtures.
+type replayTransport struct {
+	dir string
+}
+
+// NewReplayTransport is to initialise http.RoundTripper which serves the
+// responses saved by NewRecordingTransport from the fixtures directory without
+// network, request without fixture returns error.
+func NewReplayTransport(dir string) http.RoundTripper {
+	return &replayTransport{dir: dir}
+}
+
+// RoundTrip is to implement http.RoundTripper interface.
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	name, err := fixtureName(req)
+	if err != nil {
+		return nil, err
+	}
+	b, err := ioutil.ReadFile(filepath.Join(t.dir, name))
+	if err != nil {
+		return nil, fmt.Errorf("klse: no fixture for %s %s : %w", req.Method, req.URL, err)
+	}
+	return http.ReadResponse(bufio.NewReader(bytes.NewReader(b)), req)
+}
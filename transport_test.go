@@ -0,0 +1,81 @@
+package klse_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	klse "github.com/kokweikhong/klsescreener-scraper"
+	"github.com/kokweikhong/klsescreener-scraper/internal/fixture"
+)
+
+// fixturesDir is the directory of the saved klsescreener responses.
+const fixturesDir = "testdata/fixtures"
+
+// newFixtureClient is the client serves the responses from the fixtures
+// without network, or records the fixtures with -record flag.
+func newFixtureClient(t *testing.T, options ...klse.ClientOption) *klse.Client {
+	t.Helper()
+	return fixture.NewClient(fixturesDir, options...)
+}
+
+func TestFixturesRecorded(t *testing.T) {
+	handwritten, err := fixture.Handwritten(fixturesDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(handwritten) > 0 {
+		t.Skipf("hand-written fixtures, record them with go test ./... -record : %v", handwritten)
+	}
+}
+
+func TestRecordAndReplayTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<table>` + quoteResultsHead + `<tbody><tr class="list"><td title="` + string(body) + `">A</td></tr></tbody></table>`))
+	}))
+	dir := t.TempDir()
+	recorder := klse.NewClient(
+		klse.WithBaseURL(server.URL),
+		klse.WithHTTPClient(&http.Client{Transport: klse.NewRecordingTransport(dir, nil)}),
+	)
+	quote := recorder.NewQuoteResultRequest()
+	recorded, err := quote.GetQuoteResults(quote.WithMinPE(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := quote.GetQuoteResults(quote.WithMinPE(2)); err != nil {
+		t.Fatal(err)
+	}
+	server.Close()
+
+	files, _ := filepath.Glob(filepath.Join(dir, "POST_v2_screener_quote_results_*.http"))
+	if len(files) != 2 {
+		t.Fatalf("fixtures = %v, want 2 files for different form body", files)
+	}
+
+	// the server is closed, the responses are from the fixtures.
+	replayer := klse.NewClient(
+		klse.WithBaseURL("http://klsescreener.invalid"),
+		klse.WithHTTPClient(&http.Client{Transport: klse.NewReplayTransport(dir)}),
+	)
+	quote = replayer.NewQuoteResultRequest()
+	replayed, err := quote.GetQuoteResults(quote.WithMinPE(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(replayed) != 1 || replayed[0].Name != recorded[0].Name {
+		t.Errorf("replayed = %v, want %v", replayed, recorded)
+	}
+
+	_, err = quote.GetQuoteResults(quote.WithMinPE(3))
+	if err == nil || !strings.Contains(err.Error(), "no fixture") || !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("err = %v, want no fixture error", err)
+	}
+}